@@ -3,11 +3,12 @@ package main
 import (
 	"context"
 	"net/http"
+	"os"
 
-	"github.com/matzefriedrich/cobra-extensions/pkg/charmer"
-	"github.com/matzefriedrich/cobra-extensions/pkg/types"
 	"github.com/matzefriedrich/parsley/internal/commands"
 	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/migration"
+	"github.com/matzefriedrich/parsley/pkg/analyzers"
 )
 
 func main() {
@@ -18,26 +19,38 @@ func main() {
 		"Whether you're working with proxies, decorators, or need support for dynamic dependency resolution, " +
 		"Parsley CLI has you covered. Focus on your core business logic while it takes care of the heavy lifting."
 
-	app := charmer.NewCommandLineApplication("parsley-cli", description)
+	root := commands.NewRootCommand("parsley-cli", description)
 
 	writerFactoryFunc := func(projectFolder string) (generator.ScaffoldingFileWriterFunc, error) {
 		return commands.NewProjectFileScaffoldingWriterFactory(projectFolder), nil
 	}
 
-	app.AddCommand(
-		commands.NewInitCommand(writerFactoryFunc, commands.LoadProjectFromDisk),
-		commands.NewVersionCommand(&http.Client{}))
-
-	app.AddGroupCommand(
-		commands.NewGenerateGroupCommand(),
-		func(w types.CommandSetup) {
-			goFileAccessor := generator.GoFileAccessor()
-			outputWriterFactory := generator.FileOutputWriter()
-			w.AddCommand(commands.NewGenerateMocksCommand(goFileAccessor, outputWriterFactory))
-			w.AddCommand(commands.NewGenerateProxyCommand(goFileAccessor, outputWriterFactory))
-		})
+	goFileAccessor := generator.GoFileAccessor()
+	outputWriterFactory := generator.FileOutputWriter()
+
+	generateCommand := commands.NewGenerateGroupCommand(commands.LoadProjectFromDisk)
+	generateCommand.AddCommand(
+		commands.NewGenerateMocksCommand(goFileAccessor, outputWriterFactory),
+		commands.NewGenerateProxyCommand(goFileAccessor, outputWriterFactory),
+		commands.NewGenerateDecoratorCommand(goFileAccessor, outputWriterFactory),
+		commands.NewGenerateAdapterCommand(goFileAccessor, outputWriterFactory),
+		commands.NewGenerateModuleCommand(goFileAccessor, outputWriterFactory),
+		commands.NewGenerateWiringCommand(goFileAccessor, generator.GoPackageAccessor(), outputWriterFactory),
+		commands.NewGenerateInterfaceCommand(goFileAccessor, generator.GoPackageAccessor(), outputWriterFactory),
+		commands.NewGenerateConstructorCommand(goFileAccessor, outputWriterFactory),
+		commands.NewGenerateCustomCommand(goFileAccessor, generator.FileTemplateLoader(), outputWriterFactory))
+
+	root.AddCommand(
+		commands.NewInitCommand(writerFactoryFunc, commands.ProjectFileExists, commands.LoadProjectFromDisk),
+		commands.NewVersionCommand(&http.Client{}, commands.LoadProjectFromDisk),
+		commands.NewMigrateCommand(migration.DefaultRuleRegistry(), commands.LoadProjectFromDisk),
+		commands.NewVetCommand(analyzers.Analyzers()...),
+		generateCommand)
 
 	ctx := context.Background()
 
-	_ = app.Execute(ctx)
+	err := root.ExecuteContext(ctx)
+	if err != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,13 @@
+// Command parsley-vet runs the Parsley analyzers as a vet tool:
+//
+//	go vet -vettool=$(which parsley-vet) ./...
+package main
+
+import (
+	"github.com/matzefriedrich/parsley/pkg/analyzers"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(analyzers.Analyzers()...)
+}
@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// OutputFormat defines how a command reports its outcome.
+type OutputFormat string
+
+const (
+	// TextOutput prints errors as text; this is the default.
+	TextOutput OutputFormat = "text"
+	// JsonOutput prints a CommandReport as JSON after the command has been executed.
+	JsonOutput OutputFormat = "json"
+)
+
+const outputFlagName = "output"
+
+// CommandReport describes the outcome of a command execution in a structured form.
+type CommandReport struct {
+	Command  string                `json:"command"`
+	Success  bool                  `json:"success"`
+	Files    []GeneratedFileReport `json:"files"`
+	Skipped  []SkippedTypeReport   `json:"skipped"`
+	Messages []string              `json:"messages"`
+	Warnings []string              `json:"warnings"`
+	Errors   []ErrorReport         `json:"errors"`
+}
+
+// GeneratedFileReport describes a file handled by a generate command.
+type GeneratedFileReport struct {
+	Path   string                     `json:"path"`
+	Kind   string                     `json:"kind"`
+	Status generator.GenerationStatus `json:"status"`
+}
+
+// SkippedTypeReport describes an interface or func type that has been excluded from code generation by directives.
+type SkippedTypeReport struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ErrorReport describes an error and the chain of its causes.
+type ErrorReport struct {
+	Message string   `json:"message"`
+	Causes  []string `json:"causes,omitempty"`
+}
+
+// commandReporter records the outcome of a command execution. In text mode, errors are printed immediately; in JSON mode, everything is collected and printed once the command has finished.
+type commandReporter struct {
+	report CommandReport
+	errs   []error
+	format OutputFormat
+	out    io.Writer
+}
+
+type commandReporterContextKey struct{}
+
+func newCommandReporter(command string, format OutputFormat, out io.Writer) *commandReporter {
+	return &commandReporter{
+		report: CommandReport{
+			Command:  command,
+			Files:    make([]GeneratedFileReport, 0),
+			Skipped:  make([]SkippedTypeReport, 0),
+			Messages: make([]string, 0),
+			Warnings: make([]string, 0),
+			Errors:   make([]ErrorReport, 0),
+		},
+		format: format,
+		out:    out,
+	}
+}
+
+func withCommandReporter(ctx context.Context, reporter *commandReporter) context.Context {
+	return context.WithValue(ctx, commandReporterContextKey{}, reporter)
+}
+
+// commandReporterFrom returns the commandReporter of the given context, or a reporter that prints errors to stdout if the context does not carry any.
+func commandReporterFrom(ctx context.Context) *commandReporter {
+	if ctx != nil {
+		if reporter, ok := ctx.Value(commandReporterContextKey{}).(*commandReporter); ok {
+			return reporter
+		}
+	}
+	return newCommandReporter("", TextOutput, os.Stdout)
+}
+
+// fail records the given error; nil errors are ignored.
+func (r *commandReporter) fail(err error) {
+	if err == nil {
+		return
+	}
+	r.errs = append(r.errs, err)
+	report := ErrorReport{Message: err.Error()}
+	for cause := errors.Unwrap(err); cause != nil; cause = errors.Unwrap(cause) {
+		report.Causes = append(report.Causes, cause.Error())
+	}
+	r.report.Errors = append(r.report.Errors, report)
+	if r.format == TextOutput {
+		for err != nil {
+			_, _ = fmt.Fprintf(r.out, "%+v\n", err)
+			err = errors.Unwrap(err)
+		}
+	}
+}
+
+// generated records the outcome of a code file generator. It can be used as generator.CodeFileGeneratorOptions.ResultCallback.
+func (r *commandReporter) generated(result generator.GenerationResult) {
+	r.report.Files = append(r.report.Files, GeneratedFileReport{
+		Path:   result.Path,
+		Kind:   result.Kind,
+		Status: result.Status,
+	})
+}
+
+// skipped records types excluded from code generation.
+func (r *commandReporter) skipped(types ...SkippedTypeReport) {
+	r.report.Skipped = append(r.report.Skipped, types...)
+}
+
+// print records an informational message, for instance, the version of the CLI or a diff. In text mode, the message is printed immediately;
+// in JSON mode, it becomes part of the report, so that the output of the command remains valid JSON.
+func (r *commandReporter) print(format string, a ...any) {
+	message := fmt.Sprintf(format, a...)
+	if r.format == TextOutput {
+		_, _ = fmt.Fprint(r.out, message)
+		return
+	}
+	message = strings.Trim(message, "\n")
+	if message != "" {
+		r.report.Messages = append(r.report.Messages, message)
+	}
+}
+
+// warn records the given warnings. In text mode, the warnings are printed immediately; warnings do not make the command fail.
+func (r *commandReporter) warn(warnings ...string) {
+	r.report.Warnings = append(r.report.Warnings, warnings...)
+	if r.format == TextOutput {
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(r.out, "warning: %s\n", warning)
+		}
+	}
+}
+
+// complete prints the report in JSON mode and returns the joined errors recorded during the command execution.
+func (r *commandReporter) complete() error {
+	r.report.Success = len(r.errs) == 0
+	if r.format == JsonOutput {
+		encoder := json.NewEncoder(r.out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(r.report); err != nil {
+			return err
+		}
+	}
+	return errors.Join(r.errs...)
+}
+
+// outputFormatOf returns the value of the --output flag of the given command, or TextOutput if the command has no such flag.
+func outputFormatOf(cmd *cobra.Command) (OutputFormat, error) {
+	flag := cmd.Flags().Lookup(outputFlagName)
+	if flag == nil || flag.Value.String() == "" {
+		return TextOutput, nil
+	}
+	format := OutputFormat(flag.Value.String())
+	if !slices.Contains([]OutputFormat{TextOutput, JsonOutput}, format) {
+		return "", fmt.Errorf("invalid output format %q; supported formats are %q and %q", format, TextOutput, JsonOutput)
+	}
+	return format, nil
+}
+
+// createCommand creates a cobra.Command from the given typed command like commands.CreateTypedCommand does. The command gets a commandReporter via its context; if any error is reported, the command returns an error, so that the CLI exits with a non-zero code.
+func createCommand[T types.TypedCommand](instance T, options ...func() commands.CommandOption) *cobra.Command {
+	command := commands.CreateTypedCommand(instance, options...)
+	run := command.Run
+	if run == nil {
+		return command
+	}
+	command.Run = nil
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		format, err := outputFormatOf(cmd)
+		if err != nil {
+			return err
+		}
+		reporter := newCommandReporter(cmd.Name(), format, cmd.OutOrStdout())
+		cmd.SetContext(withCommandReporter(cmd.Context(), reporter))
+		run(cmd, args)
+		err = reporter.complete()
+		if err != nil {
+			// Errors have already been printed, or are part of the JSON report
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		return err
+	}
+	return command
+}
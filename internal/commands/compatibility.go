@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/migration"
+	"github.com/matzefriedrich/parsley/internal/utils"
+)
+
+// projectVersions holds the Parsley versions that a project depends on. All versions are determined offline from the go.mod file and the headers of generated files.
+type projectVersions struct {
+	cliVersion string
+	// libraryVersion is the Parsley version required by the go.mod file; empty if the project does not require Parsley.
+	libraryVersion string
+	generatedFiles []generator.GeneratedFile
+}
+
+// compatibilityWarning describes an incompatibility between Parsley versions; path refers to the affected generated file, if any.
+type compatibilityWarning struct {
+	path    string
+	message string
+}
+
+func warningMessages(warnings []compatibilityWarning) []string {
+	messages := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		messages = append(messages, warning.message)
+	}
+	return messages
+}
+
+// cliVersion returns the version of the Parsley CLI in the canonical form used by go.mod files, for instance, v1.6.0, or an empty string if the version is unknown, for instance, for development builds.
+func cliVersion() string {
+	version, err := utils.ApplicationVersion()
+	if err != nil {
+		return ""
+	}
+	return "v" + version.String()
+}
+
+// loadProjectVersions reads the Parsley version required by the given project, and collects the files generated by the Parsley CLI in the given folder, or below it, if recursive is set.
+func loadProjectVersions(p generator.GoProject, folderPath string, recursive bool) (projectVersions, error) {
+
+	versions := projectVersions{
+		cliVersion: cliVersion(),
+	}
+
+	libraryVersion, requiresParsley, err := p.DependencyVersion(parsleyModulePath)
+	if err != nil {
+		return versions, err
+	}
+	if requiresParsley {
+		versions.libraryVersion = libraryVersion
+	}
+
+	files, err := generator.FindGeneratedFiles(folderPath, recursive)
+	if err != nil {
+		return versions, err
+	}
+	versions.generatedFiles = files
+
+	return versions, nil
+}
+
+// compatibilityWarnings checks whether the Parsley CLI is compatible with the library version required by the project, and whether the generated files are compatible with the library version, or with the CLI version if the project does not require Parsley.
+// Versions are incompatible if their major versions differ, or if a migration rule describes a breaking change between them. Files that do not record the version of the CLI that generated them, or that were generated
+// by the running CLI, are not checked. If the version of the CLI is unknown, only the generated files are checked against the library version.
+func (v projectVersions) compatibilityWarnings(rules *migration.RuleRegistry) []compatibilityWarning {
+
+	warnings := make([]compatibilityWarning, 0)
+
+	if v.cliVersion != "" && v.libraryVersion != "" {
+		if reason, incompatible := incompatibility(rules, v.cliVersion, v.libraryVersion); incompatible {
+			message := fmt.Sprintf("Parsley CLI %s is not compatible with the Parsley library %s required by go.mod; %s", v.cliVersion, v.libraryVersion, reason)
+			warnings = append(warnings, compatibilityWarning{message: message})
+		}
+	}
+
+	targetName, targetVersion := "the Parsley library", v.libraryVersion
+	if targetVersion == "" {
+		targetName, targetVersion = "Parsley CLI", v.cliVersion
+	}
+	if targetVersion == "" {
+		return warnings
+	}
+
+	for _, file := range v.generatedFiles {
+		// Files generated by the running CLI are covered by the compatibility check of the CLI and the library; regenerating them would not resolve the warning
+		if file.GeneratorVersion == "" || file.GeneratorVersion == v.cliVersion {
+			continue
+		}
+		if reason, incompatible := incompatibility(rules, file.GeneratorVersion, targetVersion); incompatible {
+			message := fmt.Sprintf("%s was generated by Parsley CLI %s, which is not compatible with %s %s; %s; regenerate the file", file.Path, file.GeneratorVersion, targetName, targetVersion, reason)
+			warnings = append(warnings, compatibilityWarning{path: file.Path, message: message})
+		}
+	}
+
+	return warnings
+}
+
+// incompatibility checks the compatibility of the given versions and describes why they are incompatible. Versions that cannot be parsed, for instance, local replacements, are considered compatible.
+func incompatibility(rules *migration.RuleRegistry, a string, b string) (string, bool) {
+	compatibility, err := rules.CheckCompatibility(a, b)
+	if err != nil || compatibility.Compatible() {
+		return "", false
+	}
+	return compatibility.String(), true
+}
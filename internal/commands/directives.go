@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+)
+
+// GeneratorBehavior defines how a generator selects interfaces based on directives found in the source file.
+type GeneratorBehavior int
+
+const (
+	Default GeneratorBehavior = iota
+	OnlyMarked
+	ExcludeIgnored
+)
+
+const excludeMethodsOption = "exclude"
+
+// typeDirectiveFilter selects interfaces and func types from a model based on a marker directive and an ignore directive.
+type typeDirectiveFilter struct {
+	mark   string
+	ignore string
+}
+
+func newTypeDirectiveFilter(mark fmt.Stringer, ignore fmt.Stringer) typeDirectiveFilter {
+	return typeDirectiveFilter{
+		mark:   mark.String(),
+		ignore: ignore.String(),
+	}
+}
+
+// behavior determines the GeneratorBehavior for the given model. If any type is marked, only marked types are kept; otherwise, ignored types get removed.
+func (f typeDirectiveFilter) behavior(m *reflection.Model) GeneratorBehavior {
+
+	directives := m.Directives()
+
+	if slices.ContainsFunc(directives, isDirective(f.mark)) {
+		return OnlyMarked
+	}
+
+	if slices.ContainsFunc(directives, isDirective(f.ignore)) {
+		return ExcludeIgnored
+	}
+
+	return Default
+}
+
+// apply filters the interfaces and func types of the given model and marks interface methods excluded via the "exclude" option of the marker directive. Returns the types that have been removed from the model.
+func (f typeDirectiveFilter) apply(m *reflection.Model) []SkippedTypeReport {
+
+	skipped := make([]SkippedTypeReport, 0)
+
+	var isSkipped func(symbol reflection.SymbolInfo) bool
+	var reason string
+
+	behavior := f.behavior(m)
+	keep := make(map[uint64]reflection.Directive)
+
+	switch behavior {
+	case OnlyMarked:
+		keep = f.annotatedTypes(m, f.mark)
+		// Keep types whose identifier is in the keep map
+		isSkipped = func(symbol reflection.SymbolInfo) bool {
+			_, found := keep[symbol.Id]
+			return !found
+		}
+		reason = fmt.Sprintf("not marked with //parsley:%s", f.mark)
+	case ExcludeIgnored:
+		removed := f.annotatedTypes(m, f.ignore)
+		// Remove types whose identifier is in the removed map
+		isSkipped = func(symbol reflection.SymbolInfo) bool {
+			_, found := removed[symbol.Id]
+			return found
+		}
+		reason = fmt.Sprintf("marked with //parsley:%s", f.ignore)
+	default:
+		return skipped
+	}
+
+	m.Interfaces = slices.DeleteFunc(m.Interfaces, func(i reflection.Interface) bool {
+		if isSkipped(i.SymbolInfo) {
+			skipped = append(skipped, SkippedTypeReport{Name: i.Name, Reason: reason})
+			return true
+		}
+		return false
+	})
+	m.FuncTypes = slices.DeleteFunc(m.FuncTypes, func(t reflection.FuncType) bool {
+		if isSkipped(t.SymbolInfo) {
+			skipped = append(skipped, SkippedTypeReport{Name: t.Name, Reason: reason})
+			return true
+		}
+		return false
+	})
+
+	if behavior == OnlyMarked {
+		for i := range m.Interfaces {
+			excludeMethods(&m.Interfaces[i], keep[m.Interfaces[i].Id].Options[excludeMethodsOption])
+		}
+	}
+
+	return skipped
+}
+
+// annotatedTypes maps the identifiers of interfaces and func types that directly follow a directive with the given name to that directive.
+func (f typeDirectiveFilter) annotatedTypes(m *reflection.Model, name string) map[uint64]reflection.Directive {
+
+	symbols := make([]reflection.SymbolInfo, 0, len(m.Interfaces)+len(m.FuncTypes))
+	for _, t := range m.Interfaces {
+		symbols = append(symbols, t.SymbolInfo)
+	}
+	for _, t := range m.FuncTypes {
+		symbols = append(symbols, t.SymbolInfo)
+	}
+	slices.SortFunc(symbols, func(a, b reflection.SymbolInfo) int {
+		return int(a.Pos) - int(b.Pos)
+	})
+
+	annotated := make(map[uint64]reflection.Directive)
+	for _, directive := range m.Directives() {
+		if !directive.Is(name) {
+			continue
+		}
+		p := directive.Pos
+		for _, symbol := range symbols {
+			if symbol.Pos > p {
+				annotated[symbol.Id] = directive
+				break
+			}
+		}
+	}
+	return annotated
+}
+
+func excludeMethods(i *reflection.Interface, methodNames []string) {
+	for n, method := range i.Methods {
+		if slices.Contains(methodNames, method.Name) {
+			i.Methods[n].Excluded = true
+		}
+	}
+}
+
+func isDirective(name string) func(directive reflection.Directive) bool {
+	return func(directive reflection.Directive) bool {
+		return directive.Is(name)
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateAdapterCommand struct {
+	use                 types.CommandName `flag:"adapter" short:"Generate single-method interface adapters for func types." long:"Generates a single-method interface for each named Go func type, along with an adapter type that turns a function into an implementation of that interface. This allows handler-style functions to be used wherever an interface is expected."`
+	fileAccessor        reflection.AstFileAccessor
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// ParsleyAdapterAnnotationAttribute represents an attribute used to include or exclude func types from adapter generation based on annotations.
+type ParsleyAdapterAnnotationAttribute int
+
+const (
+	Adapter ParsleyAdapterAnnotationAttribute = iota + 1
+	NoAdapter
+)
+
+// String provides a string representation of the ParsleyAdapterAnnotationAttribute enum.
+func (a ParsleyAdapterAnnotationAttribute) String() string {
+	switch a {
+	case Adapter:
+		return "adapter"
+	case NoAdapter:
+		return "noadapter"
+	default:
+		return ""
+	}
+}
+
+// Execute generates adapters for func types. If the source file contains //parsley:adapter directives, only marked func types are processed;
+// otherwise, func types marked with //parsley:noadapter are skipped.
+func (g *generateAdapterCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	templateLoader := func(_ string) (string, error) {
+		return templates.AdapterTemplate, nil
+	}
+
+	kind := "adapter"
+	gen, _ := generator.NewCodeFileGenerator(kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
+		config.OutputWriterFactory = g.outputWriterFactory
+		config.ConfigureModelCallback = func(m *reflection.Model) {
+			reporter.skipped(newTypeDirectiveFilter(Adapter, NoAdapter).apply(m)...)
+		}
+	})
+
+	err := gen.GenerateCode()
+	reporter.fail(err)
+}
+
+var _ types.TypedCommand = &generateAdapterCommand{}
+
+// NewGenerateAdapterCommand creates a new cobra.Command for generating single-method interface adapters for func types.
+func NewGenerateAdapterCommand(fileAccessor reflection.AstFileAccessor, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateAdapterCommand{
+		fileAccessor:        fileAccessor,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
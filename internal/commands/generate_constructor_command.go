@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateConstructorCommand struct {
+	use                 types.CommandName `flag:"constructor" short:"Generate constructor functions for struct types." long:"Generates a constructor function for each specified struct type of the input source file that accepts the field values as parameters, so that the function can be registered as an activator function. Field tags control the parameters: parsley:\"-\" excludes a field, parsley:\"optional\" resolves an optional dependency, and parsley:\"name=<name>\" resolves a named dependency."`
+	Types               []string          `flag:"type" usage:"The name of the struct type to generate a constructor for; can be repeated"`
+	Context             bool              `flag:"context" usage:"Add a context.Context parameter to the constructor functions"`
+	Error               bool              `flag:"error" usage:"Add an error result to the constructor functions"`
+	fileAccessor        reflection.AstFileAccessor
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// Execute generates constructor functions for the specified struct types of the input source file.
+func (g *generateConstructorCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	templateLoader := func(_ string) (string, error) {
+		return templates.ConstructorTemplate, nil
+	}
+
+	gen, _ := generator.NewCodeFileGenerator("constructor", g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
+		config.OutputWriterFactory = g.outputWriterFactory
+		config.HashInputs = func() ([][]byte, error) {
+			return [][]byte{[]byte(strings.Join(g.Types, ",")), []byte(strconv.FormatBool(g.Context)), []byte(strconv.FormatBool(g.Error))}, nil
+		}
+		config.TemplateModelFactory = func(source *reflection.AstFileSource, model *reflection.Model) (any, error) {
+			return generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{
+				TypeNames: g.Types,
+				Context:   g.Context,
+				Error:     g.Error,
+			})
+		}
+	})
+
+	err := gen.GenerateCode()
+	reporter.fail(err)
+}
+
+var _ types.TypedCommand = (*generateConstructorCommand)(nil)
+
+// NewGenerateConstructorCommand creates a new cobra.Command that generates constructor functions for struct types.
+func NewGenerateConstructorCommand(fileAccessor reflection.AstFileAccessor, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateConstructorCommand{
+		fileAccessor:        fileAccessor,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
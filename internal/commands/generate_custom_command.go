@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/spf13/cobra"
+)
+
+var validKindPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateCustomCommand struct {
+	use                 types.CommandName `flag:"custom" short:"Generate code from a user-defined template." long:"Renders a user-defined Go template against the model of the input source file. The template has access to the same model and template functions as the built-in generators, for instance, FormattedParameters, Signature, asPublic, and asPrivate. The generated code is written to a file named <source>.<kind>.g.go."`
+	Template            string            `flag:"template" usage:"The path of the template file to render"`
+	Kind                string            `flag:"kind" usage:"The kind of the generated code, which becomes part of the output file name"`
+	fileAccessor        reflection.AstFileAccessor
+	templateLoader      generator.TemplateLoader
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// Execute renders the user-defined template against the model of the input source file. Template errors report the template file and line.
+func (g *generateCustomCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	err := g.generate(options, reporter)
+	reporter.fail(err)
+}
+
+func (g *generateCustomCommand) generate(options *GenerateOptions, reporter *commandReporter) error {
+
+	if g.Template == "" {
+		return errors.New("the --template flag is required")
+	}
+
+	if !validKindPattern.MatchString(g.Kind) {
+		return fmt.Errorf("invalid kind %q; the --kind flag is required and must only contain letters, digits, hyphens, and underscores", g.Kind)
+	}
+
+	gen, err := generator.NewCodeFileGenerator(g.Kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = g.templateLoader
+		options.apply(config, reporter)
+		config.TemplateName = g.Template
+		config.OutputWriterFactory = g.outputWriterFactory
+	})
+	if err != nil {
+		return err
+	}
+
+	return gen.GenerateCode()
+}
+
+var _ types.TypedCommand = (*generateCustomCommand)(nil)
+
+// NewGenerateCustomCommand creates a new cobra.Command that renders user-defined templates. The given TemplateLoader receives the value of the --template flag.
+func NewGenerateCustomCommand(fileAccessor reflection.AstFileAccessor, templateLoader generator.TemplateLoader, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateCustomCommand{
+		fileAccessor:        fileAccessor,
+		templateLoader:      templateLoader,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateDecoratorCommand struct {
+	use                 types.CommandName `flag:"decorator" short:"Generate decorator base types for interfaces." long:"Generates a decorator base type for each Go interface that embeds the inner value and forwards every method call to it. Embed the base type in your own decorator and override only the methods you need."`
+	fileAccessor        reflection.AstFileAccessor
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// ParsleyDecoratorAnnotationAttribute represents an attribute used to include or exclude interfaces from decorator generation based on annotations.
+type ParsleyDecoratorAnnotationAttribute int
+
+const (
+	Decorator ParsleyDecoratorAnnotationAttribute = iota + 1
+	NoDecorator
+)
+
+// String provides a string representation of the ParsleyDecoratorAnnotationAttribute enum.
+func (d ParsleyDecoratorAnnotationAttribute) String() string {
+	switch d {
+	case Decorator:
+		return "decorator"
+	case NoDecorator:
+		return "nodecorator"
+	default:
+		return ""
+	}
+}
+
+// Execute generates decorator base types. If the source file contains //parsley:decorator directives, only marked interfaces are processed;
+// otherwise, interfaces marked with //parsley:nodecorator are skipped.
+func (g *generateDecoratorCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	templateLoader := func(_ string) (string, error) {
+		return templates.DecoratorTemplate, nil
+	}
+
+	kind := "decorator"
+	gen, _ := generator.NewCodeFileGenerator(kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
+		config.OutputWriterFactory = g.outputWriterFactory
+		config.ConfigureModelCallback = func(m *reflection.Model) {
+			reporter.skipped(newTypeDirectiveFilter(Decorator, NoDecorator).apply(m)...)
+		}
+	})
+
+	err := gen.GenerateCode()
+	reporter.fail(err)
+}
+
+var _ types.TypedCommand = &generateDecoratorCommand{}
+
+// NewGenerateDecoratorCommand creates a new cobra.Command for generating decorator base types for interfaces.
+func NewGenerateDecoratorCommand(fileAccessor reflection.AstFileAccessor, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateDecoratorCommand{
+		fileAccessor:        fileAccessor,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
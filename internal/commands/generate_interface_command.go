@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateInterfaceCommand struct {
+	use                 types.CommandName `flag:"interface" short:"Generate interfaces from the methods of struct types." long:"Extracts an interface from the exported methods of a struct type, declared with value or pointer receivers in any file of the package, so that the type can be registered as a service and mocked. The generated file also asserts that the struct type implements the interface. Use the --include and --exclude flags to select the methods of the interface."`
+	Types               []string          `flag:"type" usage:"The name of the struct type to extract an interface from; can be repeated"`
+	Name                string            `flag:"name" usage:"The name of the interface; derived from the type name if not set"`
+	Include             []string          `flag:"include" usage:"The names of the methods to include; all exported methods are included if not set"`
+	Exclude             []string          `flag:"exclude" usage:"The names of the methods to exclude"`
+	fileAccessor        reflection.AstFileAccessor
+	packageAccessor     reflection.AstPackageAccessor
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// Execute extracts interfaces from the methods of the specified struct types of the package of the input source file.
+func (g *generateInterfaceCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	templateLoader := func(_ string) (string, error) {
+		return templates.InterfaceTemplate, nil
+	}
+
+	kind := "interface"
+	gen, _ := generator.NewCodeFileGenerator(kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
+		config.OutputWriterFactory = g.outputWriterFactory
+		config.HashInputs = func() ([][]byte, error) {
+			// The methods of a type can be declared in any source file of the package
+			sources, err := g.packageAccessor()
+			if err != nil {
+				return nil, err
+			}
+			inputs := [][]byte{[]byte(strings.Join(g.Types, ",")), []byte(g.Name), []byte(strings.Join(g.Include, ",")), []byte(strings.Join(g.Exclude, ","))}
+			return append(inputs, generator.PackageHashInputs(kind, sources)...), nil
+		}
+		config.TemplateModelFactory = func(_ *reflection.AstFileSource, _ *reflection.Model) (any, error) {
+			sources, err := g.packageAccessor()
+			if err != nil {
+				return nil, err
+			}
+			return generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{
+				TypeNames: g.Types,
+				Name:      g.Name,
+				Include:   g.Include,
+				Exclude:   g.Exclude,
+			})
+		}
+	})
+
+	err := gen.GenerateCode()
+	reporter.fail(err)
+}
+
+var _ types.TypedCommand = (*generateInterfaceCommand)(nil)
+
+// NewGenerateInterfaceCommand creates a new cobra.Command that generates interfaces from the methods of struct types.
+func NewGenerateInterfaceCommand(fileAccessor reflection.AstFileAccessor, packageAccessor reflection.AstPackageAccessor, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateInterfaceCommand{
+		fileAccessor:        fileAccessor,
+		packageAccessor:     packageAccessor,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
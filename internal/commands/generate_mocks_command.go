@@ -2,12 +2,7 @@ package commands
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"slices"
-	"strings"
 
-	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
 	"github.com/matzefriedrich/cobra-extensions/pkg/types"
 	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/matzefriedrich/parsley/internal/reflection"
@@ -15,21 +10,18 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const mocksKind = "mocks"
+
 //nolint:unused // The use field is used by the cobra-extensions package
 type mocksGeneratorCommand struct {
 	use                 types.CommandName `flag:"mocks" short:"Generate configurable mocks for interface types." long:"Generates fully configurable mock implementations for Go interface types. It simplifies the process of creating mocks by analyzing the source code and automatically generating mock structs that adhere to the defined interfaces."`
+	Watch               string            `flag:"watch" usage:"Watch the given directory and regenerate the mocks of changed files that have a go:generate directive for this command"`
 	fileAccessor        reflection.AstFileAccessor
 	outputWriterFactory generator.OutputWriterFactory
 }
 
 // MocksGeneratorBehavior defines different behaviors for mock generation, which influence how mocks are handled based on annotations.
-type MocksGeneratorBehavior int
-
-const (
-	Default MocksGeneratorBehavior = iota
-	OnlyMarked
-	ExcludeIgnored
-)
+type MocksGeneratorBehavior = GeneratorBehavior
 
 // ParsleyMockAnnotationAttribute represents an attribute used to manage the behavior of mocking annotations during code generation, such as including or ignoring specified interface mocks based on annotations.
 type ParsleyMockAnnotationAttribute int
@@ -53,65 +45,42 @@ func (p ParsleyMockAnnotationAttribute) String() string {
 
 // Execute generates configurable mock implementations for all relevant interface types in the input source.
 // It loads the template, configures the model, and writes the generated mocks to the specified output. The method also processes any errors encountered during code generation.
-func (m *mocksGeneratorCommand) Execute(_ context.Context) {
+// If the --watch flag is set, mocks are regenerated whenever a source file of the watched directory changes.
+func (m *mocksGeneratorCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	if m.Watch != "" {
+		reporter.fail(watchAndGenerate(ctx, m.Watch, mocksKind, reporter, m.generate))
+		return
+	}
+
+	reporter.fail(m.generate(m.fileAccessor, options, reporter))
+}
+
+func (m *mocksGeneratorCommand) generate(fileAccessor reflection.AstFileAccessor, options *GenerateOptions, reporter *commandReporter) error {
 
 	templateLoader := func(_ string) (string, error) {
 		return templates.MockTemplate, nil
 	}
 
-	kind := "mocks"
-	gen, _ := generator.NewCodeFileGenerator(kind, m.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+	gen, _ := generator.NewCodeFileGenerator(mocksKind, fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
 		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
 		config.OutputWriterFactory = m.outputWriterFactory
 		config.ConfigureModelCallback = func(m *reflection.Model) {
 			m.AddImport("github.com/matzefriedrich/parsley/pkg/features")
-			m.Interfaces = filterInterfaces(m)
+			reporter.skipped(filterMockTypes(m)...)
 		}
 	})
 
-	err := gen.GenerateCode()
-	for err != nil {
-		fmt.Printf("%+v\n", err)
-		err = errors.Unwrap(err)
-	}
+	return gen.GenerateCode()
 }
 
-func filterInterfaces(m *reflection.Model) []reflection.Interface {
-
-	behavior := determineMockGeneratorBehavior(m)
-
-	filterIdentifiers := func(attribute ParsleyMockAnnotationAttribute) []uint64 {
-		identifiers := make([]uint64, 0)
-		for _, comment := range m.Comments {
-			if isParsleyMockDirective(comment, attribute) {
-				p := comment.Pos
-				for _, t := range m.Interfaces {
-					if t.Pos > p {
-						identifiers = append(identifiers, t.Id)
-						break
-					}
-				}
-			}
-		}
-		return identifiers
-	}
-
-	switch behavior {
-	case OnlyMarked:
-		keep := filterIdentifiers(Mock)
-		// Keep interfaces whose identifier is in the keep slice
-		return slices.DeleteFunc(m.Interfaces, func(i reflection.Interface) bool {
-			return !slices.Contains(keep, i.Id)
-		})
-	case ExcludeIgnored:
-		removed := filterIdentifiers(Ignore)
-		// Remove interfaces whose identifier is in the removed slice
-		return slices.DeleteFunc(m.Interfaces, func(i reflection.Interface) bool {
-			return slices.Contains(removed, i.Id)
-		})
-	default:
-		return m.Interfaces
-	}
+func filterMockTypes(m *reflection.Model) []SkippedTypeReport {
+	filter := newTypeDirectiveFilter(Mock, Ignore)
+	return filter.apply(m)
 }
 
 var _ types.TypedCommand = (*mocksGeneratorCommand)(nil)
@@ -129,51 +98,5 @@ func NewGenerateMocksCommand(fileAccessor reflection.AstFileAccessor, outputWrit
 		fileAccessor:        fileAccessor,
 		outputWriterFactory: outputWriterFactory,
 	}
-	return commands.CreateTypedCommand(command)
-}
-
-func determineMockGeneratorBehavior(m *reflection.Model) MocksGeneratorBehavior {
-
-	hasMockAnnotations := slices.ContainsFunc(m.Comments, func(comment reflection.Comment) bool {
-		return isParsleyMockDirective(comment, Mock)
-	})
-
-	if hasMockAnnotations {
-		return OnlyMarked
-	}
-
-	hasIgnoreAnnotations := slices.ContainsFunc(m.Comments, func(comment reflection.Comment) bool {
-		return isParsleyMockDirective(comment, Ignore)
-	})
-
-	if hasIgnoreAnnotations {
-		return ExcludeIgnored
-	}
-
-	return Default
-}
-
-// isGenerateMockDirective Returns true if the comment matches the directive, otherwise false.
-func isParsleyMockDirective(comment reflection.Comment, annotation ParsleyMockAnnotationAttribute) bool {
-
-	commentText := strings.TrimSpace(comment.Text)
-	words := strings.Fields(commentText)
-
-	annotationString := annotation.String()
-	if annotationString == "" {
-		return false
-	}
-
-	expected := []string{fmt.Sprintf("//parsley:%s", annotationString)}
-
-	if len(words) >= len(expected) {
-		for i := range expected {
-			if words[i] != expected[i] {
-				return false
-			}
-		}
-		return true
-	}
-
-	return false
+	return createCommand(command)
 }
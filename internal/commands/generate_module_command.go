@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateModuleCommand struct {
+	use                 types.CommandName `flag:"module" short:"Generate a registration module from annotated constructor functions." long:"Generates a ModuleFunc that registers all constructor functions annotated with a //parsley:register directive. The directive accepts the lifetime scope (singleton, scoped, or transient) as an argument, and the options as=<ServiceType> and name=<name> to register the service as a different service type or as a named service."`
+	Name                string            `flag:"name" usage:"The name of the generated module function; derived from the source file name if not set"`
+	fileAccessor        reflection.AstFileAccessor
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// Execute generates a ModuleFunc registering all annotated constructor functions of the input source file.
+// The generation fails if an annotated function is not a valid activator function.
+func (g *generateModuleCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	templateLoader := func(_ string) (string, error) {
+		return templates.ModuleTemplate, nil
+	}
+
+	kind := "module"
+	gen, _ := generator.NewCodeFileGenerator(kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
+		config.OutputWriterFactory = g.outputWriterFactory
+		config.HashInputs = func() ([][]byte, error) {
+			return [][]byte{[]byte(g.Name)}, nil
+		}
+		config.TemplateModelFactory = func(source *reflection.AstFileSource, m *reflection.Model) (any, error) {
+			functionName := g.Name
+			if functionName == "" {
+				functionName = generator.ModuleFunctionNameFrom(source.Filename, m.PackageName)
+			}
+			return generator.NewModuleTemplateModel(m, functionName)
+		}
+	})
+
+	err := gen.GenerateCode()
+	reporter.fail(err)
+}
+
+var _ types.TypedCommand = (*generateModuleCommand)(nil)
+
+// NewGenerateModuleCommand creates a new cobra.Command that generates a registration module from annotated constructor functions.
+func NewGenerateModuleCommand(fileAccessor reflection.AstFileAccessor, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateModuleCommand{
+		fileAccessor:        fileAccessor,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
@@ -2,9 +2,7 @@ package commands
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
 	"github.com/matzefriedrich/cobra-extensions/pkg/types"
 	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/matzefriedrich/parsley/internal/reflection"
@@ -12,33 +10,74 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const proxyKind = "proxy"
+
 //nolint:unused // The use field is used by the cobra-extensions package
 type generateProxyCommand struct {
 	use                 types.CommandName `flag:"proxy" short:"Generate generic proxy types for method call interception." long:"Generates generic proxy types designed for method call interception on Go interfaces. These proxies act as intermediaries, allowing you to inject custom behavior—such as logging, validation, or transformation—before or after method execution."`
+	Watch               string            `flag:"watch" usage:"Watch the given directory and regenerate the proxies of changed files that have a go:generate directive for this command"`
 	fileAccessor        reflection.AstFileAccessor
 	outputWriterFactory generator.OutputWriterFactory
 }
 
-// Execute generates the code for a proxy.
-func (g *generateProxyCommand) Execute(_ context.Context) {
+// ParsleyProxyAnnotationAttribute represents an attribute used to include or exclude interfaces from proxy generation based on annotations.
+type ParsleyProxyAnnotationAttribute int
+
+const (
+	Proxy ParsleyProxyAnnotationAttribute = iota + 1
+	NoProxy
+)
+
+// String provides a string representation of the ParsleyProxyAnnotationAttribute enum.
+func (p ParsleyProxyAnnotationAttribute) String() string {
+	switch p {
+	case Proxy:
+		return "proxy"
+	case NoProxy:
+		return "noproxy"
+	default:
+		return ""
+	}
+}
+
+// Execute generates the code for a proxy. If the source file contains //parsley:proxy directives, only marked interfaces are processed;
+// otherwise, interfaces marked with //parsley:noproxy are skipped. Methods listed in the exclude option of a //parsley:proxy directive are forwarded without interception.
+// If the --watch flag is set, proxies are regenerated whenever a source file of the watched directory changes.
+func (g *generateProxyCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	if g.Watch != "" {
+		reporter.fail(watchAndGenerate(ctx, g.Watch, proxyKind, reporter, g.generate))
+		return
+	}
+
+	reporter.fail(g.generate(g.fileAccessor, options, reporter))
+}
+
+func (g *generateProxyCommand) generate(fileAccessor reflection.AstFileAccessor, options *GenerateOptions, reporter *commandReporter) error {
 
 	templateLoader := func(_ string) (string, error) {
 		return templates.ProxyTemplate, nil
 	}
 
-	kind := "proxy"
-	gen, _ := generator.NewCodeFileGenerator(kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+	gen, _ := generator.NewCodeFileGenerator(proxyKind, fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
 		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
 		config.OutputWriterFactory = g.outputWriterFactory
 		config.ConfigureModelCallback = func(m *reflection.Model) {
 			m.AddImport("github.com/matzefriedrich/parsley/pkg/features")
+			reporter.skipped(filterProxyTypes(m)...)
 		}
 	})
 
-	err := gen.GenerateCode()
-	if err != nil {
-		fmt.Println(err)
-	}
+	return gen.GenerateCode()
+}
+
+func filterProxyTypes(m *reflection.Model) []SkippedTypeReport {
+	filter := newTypeDirectiveFilter(Proxy, NoProxy)
+	return filter.apply(m)
 }
 
 var _ types.TypedCommand = &generateProxyCommand{}
@@ -49,5 +88,5 @@ func NewGenerateProxyCommand(fileAccessor reflection.AstFileAccessor, outputWrit
 		fileAccessor:        fileAccessor,
 		outputWriterFactory: outputWriterFactory,
 	}
-	return commands.CreateTypedCommand(command)
+	return createCommand(command)
 }
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/watch"
+)
+
+// generateFunc generates code for the source file provided by the given accessor and records the outcome with the reporter.
+type generateFunc func(fileAccessor reflection.AstFileAccessor, options *GenerateOptions, reporter *commandReporter) error
+
+// watchAndGenerate regenerates the code of the given kind whenever a Go source file below the directory changes, until the command is interrupted.
+// Only files with a //go:generate directive that runs the generate command for the kind are regenerated; each changed file is parsed again. Errors are printed and do not stop the watcher.
+func watchAndGenerate(ctx context.Context, dir string, kind string, reporter *commandReporter, generate generateFunc, config ...watch.WatcherOptionsFunc) error {
+
+	options := GenerateOptionsFrom(ctx)
+	if options.Check {
+		return errors.New("the --watch flag cannot be combined with the --check flag")
+	}
+	if reporter.format != TextOutput {
+		return fmt.Errorf("the --watch flag supports %q output only", TextOutput)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := watch.NewWatcher(dir, config...)
+	if err != nil {
+		return err
+	}
+
+	regenerate := func(paths []string) {
+		for _, path := range paths {
+			content, readErr := os.ReadFile(path)
+			if errors.Is(readErr, os.ErrNotExist) {
+				continue
+			}
+			fileReporter := newCommandReporter(reporter.report.Command, TextOutput, reporter.out)
+			if readErr != nil {
+				fileReporter.fail(readErr)
+				continue
+			}
+			if !generator.HasGenerateDirective(content, kind) {
+				continue
+			}
+			fileReporter.fail(generate(reflection.AstFromFile(path), options, fileReporter))
+			for _, file := range fileReporter.report.Files {
+				if file.Status == generator.Generated {
+					_, _ = fmt.Fprintf(reporter.out, "generated %s\n", file.Path)
+				}
+			}
+		}
+	}
+
+	// Bring outdated files up to date first; files whose source hash is unchanged are skipped
+	paths, err := watch.GoFiles(dir)
+	if err != nil {
+		return err
+	}
+	regenerate(paths)
+
+	_, _ = fmt.Fprintf(reporter.out, "watching %s for changes to regenerate %s; press Ctrl+C to stop\n", dir, kind)
+	return watcher.Watch(ctx, regenerate)
+}
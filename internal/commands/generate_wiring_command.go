@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type generateWiringCommand struct {
+	use                 types.CommandName `flag:"wiring" short:"Generate a container that wires services without runtime reflection." long:"Statically analyzes the ModuleFunc functions, //parsley:register directives, and the root constructor function of a package, and generates a container type that builds the object graph with direct function calls. The container implements types.Resolver and preserves singleton and scoped lifetimes, so existing code calling ResolveRequiredService keeps working."`
+	Root                string            `flag:"root" usage:"The name of the root constructor function, for instance, the application factory"`
+	Name                string            `flag:"name" usage:"The name of the generated container type"`
+	Modules             []string          `flag:"module" usage:"The names of the module functions to analyze; all module functions of the package are analyzed if not set"`
+	fileAccessor        reflection.AstFileAccessor
+	packageAccessor     reflection.AstPackageAccessor
+	outputWriterFactory generator.OutputWriterFactory
+}
+
+// Execute analyzes the package of the input source file and generates a container that wires all registered services with direct calls.
+func (g *generateWiringCommand) Execute(ctx context.Context) {
+
+	options := GenerateOptionsFrom(ctx)
+	reporter := commandReporterFrom(ctx)
+
+	templateLoader := func(_ string) (string, error) {
+		return templates.WiringTemplate, nil
+	}
+
+	kind := "wiring"
+	gen, _ := generator.NewCodeFileGenerator(kind, g.fileAccessor, func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = templateLoader
+		options.apply(config, reporter)
+		config.OutputWriterFactory = g.outputWriterFactory
+		config.HashInputs = func() ([][]byte, error) {
+			// The container depends on all source files of the package, not only on the input source file
+			sources, err := g.packageAccessor()
+			if err != nil {
+				return nil, err
+			}
+			inputs := [][]byte{[]byte(g.Root), []byte(g.Name), []byte(strings.Join(g.Modules, ","))}
+			return append(inputs, generator.PackageHashInputs(kind, sources)...), nil
+		}
+		config.TemplateModelFactory = func(_ *reflection.AstFileSource, _ *reflection.Model) (any, error) {
+			sources, err := g.packageAccessor()
+			if err != nil {
+				return nil, err
+			}
+			return generator.NewWiringTemplateModel(sources, generator.WiringOptions{
+				ContainerName: g.Name,
+				Root:          g.Root,
+				Modules:       g.Modules,
+			})
+		}
+	})
+
+	err := gen.GenerateCode()
+	reporter.fail(err)
+}
+
+var _ types.TypedCommand = (*generateWiringCommand)(nil)
+
+// NewGenerateWiringCommand creates a new cobra.Command that generates a reflection-free container for the services of a package.
+func NewGenerateWiringCommand(fileAccessor reflection.AstFileAccessor, packageAccessor reflection.AstPackageAccessor, outputWriterFactory generator.OutputWriterFactory) *cobra.Command {
+	command := &generateWiringCommand{
+		Root:                "NewApp",
+		fileAccessor:        fileAccessor,
+		packageAccessor:     packageAccessor,
+		outputWriterFactory: outputWriterFactory,
+	}
+	return createCommand(command)
+}
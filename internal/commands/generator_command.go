@@ -2,9 +2,15 @@ package commands
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
 	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/migration"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +25,97 @@ func (g *generatorCommand) Execute(_ context.Context) {
 
 var _ types.TypedCommand = &generatorCommand{}
 
-func NewGenerateGroupCommand() *cobra.Command {
+type generateOptionsContextKey struct{}
+
+// GenerateOptions holds the options shared by all generate subcommands. The options are bound to persistent flags of the generate command group.
+type GenerateOptions struct {
+	// Check compares the generated code with the existing files instead of writing it.
+	Check bool
+	// OutputReaderFactory reads previously generated code to detect whether the code is outdated, or whether generation can be skipped.
+	OutputReaderFactory generator.OutputReaderFactory
+	// GeneratorVersion is the version of the Parsley CLI recorded in the header of generated files.
+	GeneratorVersion string
+	warnings         []compatibilityWarning
+}
+
+// WithGenerateOptions returns a copy of the given context that carries the specified GenerateOptions.
+func WithGenerateOptions(ctx context.Context, options *GenerateOptions) context.Context {
+	return context.WithValue(ctx, generateOptionsContextKey{}, options)
+}
+
+// GenerateOptionsFrom returns the GenerateOptions of the given context, or default options if the context does not carry any.
+func GenerateOptionsFrom(ctx context.Context) *GenerateOptions {
+	if ctx != nil {
+		if options, ok := ctx.Value(generateOptionsContextKey{}).(*GenerateOptions); ok {
+			return options
+		}
+	}
+	return &GenerateOptions{}
+}
+
+// apply configures the given code file generator options, and records the outcome of the generator with the given reporter.
+func (o *GenerateOptions) apply(config *generator.CodeFileGeneratorOptions, reporter *commandReporter) {
+	config.Check = o.Check
+	config.OutputReaderFactory = o.OutputReaderFactory
+	config.GeneratorVersion = o.GeneratorVersion
+	config.ResultCallback = func(result generator.GenerationResult) {
+		reporter.generated(result)
+		o.reportWarnings(reporter, result)
+	}
+}
+
+// reportWarnings passes the pending compatibility warnings to the reporter once the outcome of the generator is known. Warnings about the file that has just been regenerated are dropped.
+func (o *GenerateOptions) reportWarnings(reporter *commandReporter, result generator.GenerationResult) {
+	for _, warning := range o.warnings {
+		if result.Status == generator.Generated && warning.path != "" && filepath.Clean(warning.path) == filepath.Clean(result.Path) {
+			continue
+		}
+		reporter.warn(warning.message)
+	}
+	o.warnings = nil
+}
+
+// checkCompatibility collects warnings about generated files in the current directory that are not compatible with the Parsley library required by the project; the warnings are reported by the subcommand, see reportWarnings. Nothing is checked outside a module.
+func (o *GenerateOptions) checkCompatibility(projectLoadFunc ProjectLoaderFunc) {
+
+	projectFolderPath, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	p, err := projectLoadFunc(projectFolderPath)
+	if errors.Is(err, generator.ErrModFileNotFound) {
+		return
+	}
+	if err != nil {
+		o.warnings = append(o.warnings, compatibilityWarning{message: fmt.Sprintf("cannot check the compatibility of the Parsley versions: %v", err)})
+		return
+	}
+
+	versions, err := loadProjectVersions(p, projectFolderPath, false)
+	if err != nil {
+		o.warnings = append(o.warnings, compatibilityWarning{message: fmt.Sprintf("cannot check the compatibility of the Parsley versions: %v", err)})
+		return
+	}
+	o.warnings = append(o.warnings, versions.compatibilityWarnings(migration.DefaultRuleRegistry())...)
+}
+
+// NewGenerateGroupCommand creates the generate command group. The --check flag is available to all subcommands; if any generated file is outdated, the command returns an error.
+// Before a subcommand runs, the project is loaded using the given function to warn about generated files that are not compatible with the Parsley library version in use.
+func NewGenerateGroupCommand(projectLoaderFunc ProjectLoaderFunc) *cobra.Command {
 	command := &generatorCommand{}
-	return commands.CreateTypedCommand(command, commands.NonRunnable)
+	groupCommand := commands.CreateTypedCommand(command, commands.NonRunnable)
+
+	options := &GenerateOptions{
+		OutputReaderFactory: generator.FileOutputReader(),
+		GeneratorVersion:    cliVersion(),
+	}
+
+	groupCommand.PersistentFlags().BoolVar(&options.Check, "check", false, "Compare the generated code with the existing files and print a diff instead of writing them; fails if any file is outdated")
+	groupCommand.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		options.checkCompatibility(projectLoaderFunc)
+		cmd.SetContext(WithGenerateOptions(cmd.Context(), options))
+	}
+
+	return groupCommand
 }
@@ -2,67 +2,136 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 
-	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
 	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/migration"
 	"github.com/matzefriedrich/parsley/internal/utils"
 
 	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/spf13/cobra"
 )
 
+// develLibraryVersion is the Parsley library version required by projects that are initialized by a development build of the CLI, whose version is unknown.
+const develLibraryVersion = "v1.6.0"
+
 // ScaffoldingFileWriterFactoryFunc defines a function type that returns a generator.ScaffoldingFileWriterFunc.
 type ScaffoldingFileWriterFactoryFunc func(projectFolder string) (generator.ScaffoldingFileWriterFunc, error)
 
+// ScaffoldingFileExistsFactoryFunc defines a function type that returns a generator.ScaffoldingFileExistsFunc for the given project folder.
+type ScaffoldingFileExistsFactoryFunc func(projectFolder string) generator.ScaffoldingFileExistsFunc
+
 // ProjectLoaderFunc is a function type that loads a Go project given a project folder path.
 type ProjectLoaderFunc func(projectFolderPath string) (generator.GoProject, error)
 
 //nolint:unused // The use field is used by the cobra-extensions package
 type initCommand struct {
-	use                   types.CommandName `flag:"init" short:"Add Parsley to an application" long:"Integrates Parsley into an existing application by setting up the necessary scaffolding for dependency injection and code generation. It initializes project configurations, generates essential files, and prepares the application for using Parsley's advanced features."`
+	use                   types.CommandName `flag:"init" short:"Add Parsley to an application" long:"Integrates Parsley into an existing application by setting up the necessary scaffolding for dependency injection and code generation. It initializes project configurations, generates essential files, and prepares the application for using Parsley's advanced features. Existing files are not overwritten unless the --force flag is set."`
+	Template              string            `flag:"template" usage:"The project template to scaffold; one of cli, http-server, or worker"`
+	Force                 bool              `flag:"force" usage:"Overwrite existing project files"`
+	DryRun                bool              `flag:"dry-run" usage:"Print the files that would be written without changing the project"`
 	fileWriterFactoryFunc ScaffoldingFileWriterFactoryFunc
+	fileExistsFactoryFunc ScaffoldingFileExistsFactoryFunc
 	projectLoadFunc       ProjectLoaderFunc
 }
 
-// Execute sets up a new project by loading the current project folder, adding necessary dependencies,
-// and generating initial project files. It handles errors and ensures the minimum required version of dependencies.
-func (g *initCommand) Execute(_ context.Context) {
+// Execute sets up a new project by loading the current project folder, generating initial project files, and adding necessary dependencies.
+// The project files are scaffolded first, so that the project remains unchanged if a file cannot be generated, or if a file already exists.
+// Afterward, the command warns if the Parsley CLI, the library version required by the project, and previously generated files are not compatible with each other.
+func (g *initCommand) Execute(ctx context.Context) {
+	reporter := commandReporterFrom(ctx)
+	reporter.fail(g.initialize(reporter))
+}
+
+func (g *initCommand) initialize(reporter *commandReporter) error {
+
+	projectFolderPath, err := os.Getwd()
+	if err != nil {
+		return err
+	}
 
-	projectFolderPath, _ := os.Getwd()
 	p, err := g.projectLoadFunc(projectFolderPath)
 	if err != nil {
-		fmt.Println(err)
-		return
+		return err
 	}
 
-	minVersion, _ := utils.ApplicationVersion()
-	const packageName = "github.com/matzefriedrich/parsley"
-	dependencyErr := p.AddDependency(packageName, minVersion.String())
-	if dependencyErr != nil {
-		fmt.Println(err)
-		return
+	libraryVersion := develLibraryVersion
+	if minVersion, versionErr := utils.ApplicationVersion(); versionErr == nil {
+		libraryVersion = "v" + minVersion.String()
 	}
 
-	fileWriterFunc, _ := g.fileWriterFactoryFunc(projectFolderPath)
+	fileWriterFunc, err := g.fileWriterFactoryFunc(projectFolderPath)
+	if err != nil {
+		return err
+	}
+
+	gen := generator.NewBootstrapGenerator(fileWriterFunc, func(config *generator.BootstrapGeneratorOptions) {
+		config.Template = g.Template
+		config.Force = g.Force
+		config.DryRun = g.DryRun
+		config.FileExists = g.fileExistsFactoryFunc(projectFolderPath)
+		config.GeneratorVersion = cliVersion()
+	})
 
-	gen := generator.NewBootstrapGenerator(fileWriterFunc)
-	gen.ScaffoldProjectFiles()
+	files, err := gen.ScaffoldProjectFiles()
+	g.printScaffoldedFiles(reporter, files)
+	if err != nil {
+		if errors.Is(err, generator.ErrProjectFilesAlreadyExist) {
+			reporter.print("Use the --force flag to overwrite existing project files.\n")
+		}
+		return err
+	}
+
+	if g.DryRun {
+		reporter.print("would add %s %s to go.mod\n", parsleyModulePath, libraryVersion)
+	} else {
+		err = p.AddDependency(parsleyModulePath, libraryVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	versions, err := loadProjectVersions(p, projectFolderPath, true)
+	if err != nil {
+		reporter.warn(fmt.Sprintf("cannot check the compatibility of the Parsley versions: %v", err))
+		return nil
+	}
+	reporter.warn(warningMessages(versions.compatibilityWarnings(migration.DefaultRuleRegistry()))...)
+
+	return nil
+}
+
+func (g *initCommand) printScaffoldedFiles(reporter *commandReporter, files []generator.ScaffoldedFile) {
+	for _, file := range files {
+		switch {
+		case file.Exists && !g.Force:
+			reporter.print("%s already exists\n", file.Filename)
+		case g.DryRun && file.Exists:
+			reporter.print("would overwrite %s\n", file.Filename)
+		case g.DryRun:
+			reporter.print("would create %s\n", file.Filename)
+		}
+	}
 }
 
 var _ types.TypedCommand = &initCommand{}
 
+// NewInitCommand creates a new cobra.Command that scaffolds a Parsley application from one of the supported project templates.
 func NewInitCommand(
 	writerFactoryFunc ScaffoldingFileWriterFactoryFunc,
+	fileExistsFactoryFunc ScaffoldingFileExistsFactoryFunc,
 	projectLoaderFunc ProjectLoaderFunc) *cobra.Command {
 	command := &initCommand{
+		Template:              generator.DefaultProjectTemplate,
 		fileWriterFactoryFunc: writerFactoryFunc,
+		fileExistsFactoryFunc: fileExistsFactoryFunc,
 		projectLoadFunc:       projectLoaderFunc,
 	}
-	return commands.CreateTypedCommand(command)
+	return createCommand(command)
 }
 
 // NewProjectFileScaffoldingWriterFactory creates a factory for generating file writers in a specified project directory.
@@ -77,6 +146,20 @@ func NewProjectFileScaffoldingWriterFactory(projectFolderPath string) generator.
 	}
 }
 
+// ProjectFileExists creates a generator.ScaffoldingFileExistsFunc that checks for files in the specified project directory.
+func ProjectFileExists(projectFolderPath string) generator.ScaffoldingFileExistsFunc {
+	return func(targetFilename string) (bool, error) {
+		_, err := os.Stat(path.Join(projectFolderPath, targetFilename))
+		if err == nil {
+			return true, nil
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+}
+
 // LoadProjectFromDisk loads a Go project from the specified directory path.
 func LoadProjectFromDisk(projectFolderPath string) (generator.GoProject, error) {
 	return generator.OpenProject(projectFolderPath)
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/migration"
+	"github.com/matzefriedrich/parsley/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const parsleyModulePath = "github.com/matzefriedrich/parsley"
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type migrateCommand struct {
+	use             types.CommandName `flag:"migrate" short:"Rewrite code affected by breaking changes between Parsley versions" long:"Applies the migration rules for all breaking changes introduced after the --from version, up to and including the --to version, to the Go source files of the module in the current directory, and updates the required Parsley version in the go.mod file. A unified diff of each changed file is printed; use the --dry-run flag to preview the changes without writing them."`
+	From            string            `flag:"from" usage:"The Parsley version to migrate from; defaults to the version required by the go.mod file"`
+	To              string            `flag:"to" usage:"The Parsley version to migrate to, for instance, v1.6"`
+	DryRun          bool              `flag:"dry-run" usage:"Print the diff preview without changing any file"`
+	rules           *migration.RuleRegistry
+	projectLoadFunc ProjectLoaderFunc
+}
+
+// Execute rewrites the source files of the module in the current directory using the migration rules between the --from and --to versions.
+func (m *migrateCommand) Execute(ctx context.Context) {
+	reporter := commandReporterFrom(ctx)
+	reporter.fail(m.migrate(reporter))
+}
+
+func (m *migrateCommand) migrate(reporter *commandReporter) error {
+
+	if m.To == "" {
+		return errors.New("the --to flag is required")
+	}
+
+	to, err := migration.CanonicalVersion(m.To)
+	if err != nil {
+		return err
+	}
+
+	projectFolderPath, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	p, err := m.projectLoadFunc(projectFolderPath)
+	if err != nil {
+		return err
+	}
+
+	requiredVersion, requiresParsley, err := p.DependencyVersion(parsleyModulePath)
+	if err != nil {
+		return err
+	}
+
+	from := m.From
+	if from == "" {
+		if !requiresParsley {
+			return fmt.Errorf("the module does not require %s; use the --from flag to specify the version to migrate from", parsleyModulePath)
+		}
+		from = requiredVersion
+	}
+
+	rules, err := m.rules.RulesBetween(from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		reporter.print("%s %s: %s\n", rule.Version, rule.Name, rule.Description)
+	}
+
+	changes, err := migration.Migrate(projectFolderPath, rules)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		name, _ := filepath.Rel(projectFolderPath, change.Filename)
+		reporter.print("%s", utils.UnifiedDiff("a/"+name, "b/"+name, change.Original, change.Migrated))
+	}
+
+	updateModFile := requiresParsley && requiredVersion != to
+	if updateModFile {
+		reporter.print("go.mod: require %s %s => %s\n", parsleyModulePath, requiredVersion, to)
+	}
+
+	if len(changes) == 0 && !updateModFile {
+		reporter.print("The module is up to date; no changes required.\n")
+		return nil
+	}
+
+	if m.DryRun {
+		return nil
+	}
+
+	for _, change := range changes {
+		err := writeMigratedFile(change)
+		if err != nil {
+			return err
+		}
+	}
+
+	if updateModFile {
+		return p.SetDependencyVersion(parsleyModulePath, to)
+	}
+
+	return nil
+}
+
+func writeMigratedFile(change migration.FileChange) error {
+	info, err := os.Stat(change.Filename)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(change.Filename, change.Migrated, info.Mode().Perm())
+}
+
+var _ types.TypedCommand = (*migrateCommand)(nil)
+
+// NewMigrateCommand creates a new cobra.Command that migrates the module in the current directory between Parsley versions using the rules of the given registry.
+func NewMigrateCommand(rules *migration.RuleRegistry, projectLoaderFunc ProjectLoaderFunc) *cobra.Command {
+	command := &migrateCommand{
+		rules:           rules,
+		projectLoadFunc: projectLoaderFunc,
+	}
+	return createCommand(command)
+}
@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"github.com/matzefriedrich/cobra-extensions/pkg/charmer"
+	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
+	"github.com/spf13/cobra"
+)
+
+// NewRootCommand creates the root command of the Parsley CLI, including the markdown docs command. The --output flag is available to all
+// commands; with --output json, every command prints a CommandReport instead of text.
+func NewRootCommand(name string, description string) *cobra.Command {
+	root := charmer.NewRootCommand(name, description)
+	root.PersistentFlags().String(outputFlagName, string(TextOutput), "The output format; use json to report messages, generated files, skipped types, warnings, and errors in a structured form")
+	root.AddCommand(commands.NewMarkdownDocsCommand(root))
+	return root
+}
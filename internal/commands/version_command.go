@@ -2,29 +2,39 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 
-	"github.com/matzefriedrich/cobra-extensions/pkg/commands"
 	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/migration"
 	"github.com/matzefriedrich/parsley/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 //nolint:unused // The use field is used by the cobra-extensions package
 type versionCommand struct {
-	use            types.CommandName `flag:"version" short:"Show the current Parsley CLI version"`
-	CheckForUpdate bool              `flag:"check-update" usage:"Checks for available updates and prints the update command"`
-	httpClient     utils.HttpClient
+	use             types.CommandName `flag:"version" short:"Show the current Parsley CLI version" long:"Shows the version of the Parsley CLI and the version of the Parsley library required by the go.mod file of the current module. Warns if the CLI, the library, or the CLI versions recorded in generated files are not compatible with each other; this check works offline. Use the --check-update flag to query the latest release from GitHub."`
+	CheckForUpdate  bool              `flag:"check-update" usage:"Checks for available updates and prints the update command"`
+	httpClient      utils.HttpClient
+	projectLoadFunc ProjectLoaderFunc
 }
 
 // Execute displays the current Parsley CLI version and checks for updates if enabled. Shows update instructions if a new version exists.
 func (v *versionCommand) Execute(ctx context.Context) {
 
+	reporter := commandReporterFrom(ctx)
+
 	appVersion, appVersionErr := utils.ApplicationVersion()
 	if appVersionErr == nil {
-		fmt.Printf("Parsley CLI v%s\n", appVersion.String())
+		reporter.print("Parsley CLI v%s\n", appVersion.String())
+	} else {
+		reporter.print("Parsley CLI (devel)\n")
 	}
 
+	v.checkProjectCompatibility(reporter)
+
 	if !v.CheckForUpdate {
 		return
 	}
@@ -32,6 +42,7 @@ func (v *versionCommand) Execute(ctx context.Context) {
 	githubClient := utils.NewGitHubApiClient(v.httpClient)
 	release, err := githubClient.QueryLatestReleaseTag(ctx)
 	if err != nil {
+		reporter.fail(fmt.Errorf("failed to check for updates: %w", err))
 		return
 	}
 
@@ -39,29 +50,61 @@ func (v *versionCommand) Execute(ctx context.Context) {
 	if appVersionErr == nil && releaseVersionErr == nil {
 		if appVersion.LessThan(*releaseVersion) {
 
-			fmt.Printf("\n"+
+			reporter.print("\n"+
 				"Your version of Parsley CLI is out of date!\n\n"+
 				"The latest version is: v%s.\n"+
 				"To update run the following command: "+
 				"go install github.com/matzefriedrich/parsley/cmd/parsley-cli@v%s\n\n", releaseVersion.String(), releaseVersion.String())
 
-			fmt.Printf("More information about the release %s is available at:\n%s\n", release.Name, release.HtmlUrl)
+			reporter.print("More information about the release %s is available at:\n%s\n", release.Name, release.HtmlUrl)
 
 		} else if appVersion.Equal(*releaseVersion) {
 
-			fmt.Printf("\n" +
+			reporter.print("\n" +
 				"You are using the latest version of Parsley CLI.\n\n")
 
 		}
 	}
 }
 
+// checkProjectCompatibility prints the Parsley library version required by the module in the current directory, and warns about incompatible versions. Nothing is printed outside a module.
+func (v *versionCommand) checkProjectCompatibility(reporter *commandReporter) {
+
+	projectFolderPath, err := os.Getwd()
+	if err != nil {
+		reporter.fail(err)
+		return
+	}
+
+	p, err := v.projectLoadFunc(projectFolderPath)
+	if errors.Is(err, generator.ErrModFileNotFound) {
+		return
+	}
+	if err != nil {
+		reporter.warn(fmt.Sprintf("cannot check the compatibility of the Parsley versions: %v", err))
+		return
+	}
+
+	versions, err := loadProjectVersions(p, projectFolderPath, true)
+	if err != nil {
+		reporter.warn(fmt.Sprintf("cannot check the compatibility of the Parsley versions: %v", err))
+		return
+	}
+
+	if versions.libraryVersion != "" {
+		reporter.print("Parsley library %s (go.mod)\n", versions.libraryVersion)
+	}
+
+	reporter.warn(warningMessages(versions.compatibilityWarnings(migration.DefaultRuleRegistry()))...)
+}
+
 var _ types.TypedCommand = (*versionCommand)(nil)
 
-// NewVersionCommand creates a new cobra.Command that displays the current version of the Parsley CLI and checks for updates.
-func NewVersionCommand(httpClient utils.HttpClient) *cobra.Command {
+// NewVersionCommand creates a new cobra.Command that displays the current version of the Parsley CLI and of the Parsley library used by the current module, and checks for updates.
+func NewVersionCommand(httpClient utils.HttpClient, projectLoaderFunc ProjectLoaderFunc) *cobra.Command {
 	command := &versionCommand{
-		httpClient: httpClient,
+		httpClient:      httpClient,
+		projectLoadFunc: projectLoaderFunc,
 	}
-	return commands.CreateTypedCommand(command)
+	return createCommand(command)
 }
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/matzefriedrich/cobra-extensions/pkg/types"
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+//nolint:unused // The use field is used by the cobra-extensions package
+type vetCommand struct {
+	use       types.CommandName `flag:"vet" short:"Report common misuse of the Parsley API" long:"Runs the Parsley analyzers on the given packages, or on all packages of the module in the current directory (./...), and reports registrations of invalid activator functions, unchecked registration errors, unsupported service types, and activator functions that do not accept a context.Context as their first parameter. Use the --fix flag to apply the suggested fixes."`
+	Fix       bool              `flag:"fix" usage:"Apply the suggested fixes"`
+	patterns  []string
+	analyzers []*analysis.Analyzer
+}
+
+type vetDiagnostic struct {
+	analysis.Diagnostic
+	analyzer *analysis.Analyzer
+	fileSet  *token.FileSet
+}
+
+func (d vetDiagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.fileSet.Position(d.Pos), d.Message, d.analyzer.Name)
+}
+
+// Execute runs the analyzers on the packages specified by the command arguments.
+func (v *vetCommand) Execute(ctx context.Context) {
+	reporter := commandReporterFrom(ctx)
+	reporter.fail(v.vet(reporter))
+}
+
+// maxFixPasses limits how often the packages are analyzed again after fixes have been applied; fixes that overlap with other fixes, for instance, nested ones, are applied in a later pass.
+const maxFixPasses = 5
+
+func (v *vetCommand) vet(reporter *commandReporter) error {
+
+	patterns := v.patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	diagnostics, err := v.analyze(patterns)
+	if err != nil {
+		return err
+	}
+
+	for fixPass := 0; v.Fix && fixPass < maxFixPasses; fixPass++ {
+		fixed, err := applySuggestedFixes(reporter, diagnostics)
+		if err != nil {
+			return err
+		}
+		if fixed == 0 {
+			break
+		}
+		diagnostics, err = v.analyze(patterns)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, diagnostic := range diagnostics {
+		reporter.print("%s\n", diagnostic)
+	}
+
+	if len(diagnostics) > 0 {
+		return fmt.Errorf("found %d problems", len(diagnostics))
+	}
+	return nil
+}
+
+func (v *vetCommand) analyze(patterns []string) ([]vetDiagnostic, error) {
+
+	config := &packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(config, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	err = packageErrors(pkgs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load packages: %w", err)
+	}
+
+	graph, err := checker.Analyze(v.analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectDiagnostics(graph)
+}
+
+func packageErrors(pkgs []*packages.Package) error {
+	errs := make([]error, 0)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// collectDiagnostics returns the diagnostics of all root actions. Duplicates are removed, since files can belong to multiple packages; for instance, to a package and its test variant.
+func collectDiagnostics(graph *checker.Graph) ([]vetDiagnostic, error) {
+	type key struct {
+		position token.Position
+		message  string
+	}
+	seen := make(map[key]bool)
+	diagnostics := make([]vetDiagnostic, 0)
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			return nil, fmt.Errorf("%s: %w", action, action.Err)
+		}
+		for _, diagnostic := range action.Diagnostics {
+			k := key{position: action.Package.Fset.Position(diagnostic.Pos), message: diagnostic.Message}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			diagnostics = append(diagnostics, vetDiagnostic{
+				Diagnostic: diagnostic,
+				analyzer:   action.Analyzer,
+				fileSet:    action.Package.Fset,
+			})
+		}
+	}
+	slices.SortFunc(diagnostics, func(a, b vetDiagnostic) int {
+		positionA, positionB := a.fileSet.Position(a.Pos), b.fileSet.Position(b.Pos)
+		return cmp.Or(strings.Compare(positionA.Filename, positionB.Filename), positionA.Offset-positionB.Offset)
+	})
+	return diagnostics, nil
+}
+
+type fileEdit struct {
+	start int
+	end   int
+	text  []byte
+}
+
+// applySuggestedFixes applies the first suggested fix of each diagnostic, rewrites the affected files, and returns the number of applied fixes. Fixes that overlap with a previously accepted fix are skipped.
+func applySuggestedFixes(reporter *commandReporter, diagnostics []vetDiagnostic) (int, error) {
+
+	fixed := 0
+	edits := make(map[string][]fileEdit)
+	for _, diagnostic := range diagnostics {
+		if len(diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+		fix := diagnostic.SuggestedFixes[0]
+		filename := ""
+		candidates := make([]fileEdit, 0, len(fix.TextEdits))
+		for _, textEdit := range fix.TextEdits {
+			start, end := diagnostic.fileSet.Position(textEdit.Pos), diagnostic.fileSet.Position(textEdit.End)
+			if textEdit.End == token.NoPos {
+				end = start
+			}
+			filename = start.Filename
+			candidates = append(candidates, fileEdit{start: start.Offset, end: end.Offset, text: textEdit.NewText})
+		}
+		if overlapsAny(edits[filename], candidates) {
+			continue
+		}
+		edits[filename] = append(edits[filename], candidates...)
+		fixed++
+		reporter.print("fixed %s\n", diagnostic)
+	}
+
+	for filename, fileEdits := range edits {
+		err := applyFileEdits(filename, fileEdits)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return fixed, nil
+}
+
+func overlapsAny(accepted []fileEdit, candidates []fileEdit) bool {
+	for _, candidate := range candidates {
+		for _, e := range accepted {
+			if candidate.start < e.end && e.start < candidate.end || candidate.start == e.start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func applyFileEdits(filename string, edits []fileEdit) error {
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(edits, func(a, b fileEdit) int {
+		return a.start - b.start
+	})
+
+	fixed := make([]byte, 0, len(content))
+	last := 0
+	for _, e := range edits {
+		fixed = append(fixed, content[last:e.start]...)
+		fixed = append(fixed, e.text...)
+		last = e.end
+	}
+	fixed = append(fixed, content[last:]...)
+
+	formatted, err := format.Source(fixed)
+	if err != nil {
+		return fmt.Errorf("%s: cannot format fixed code: %w", filename, err)
+	}
+	return os.WriteFile(filename, formatted, info.Mode().Perm())
+}
+
+var _ types.TypedCommand = (*vetCommand)(nil)
+
+// NewVetCommand creates a new cobra.Command that runs the given analyzers on the package patterns passed as arguments.
+func NewVetCommand(analyzers ...*analysis.Analyzer) *cobra.Command {
+	command := &vetCommand{
+		analyzers: analyzers,
+	}
+	c := createCommand(command)
+	c.Use = "vet [packages]"
+	c.Args = cobra.ArbitraryArgs
+	run := c.RunE
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		command.patterns = args
+		return run(cmd, args)
+	}
+	return c
+}
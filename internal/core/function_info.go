@@ -62,7 +62,10 @@ func ReflectFunctionInfoFrom(value reflect.Value) (types.FunctionInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	parameters := parameterInfos(funcType)
+	parameters, err := parameterInfos(funcType)
+	if err != nil {
+		return nil, err
+	}
 	hasContext := false
 	if len(parameters) > 0 {
 		firstParamType := parameters[0].Type().ReflectedType()
@@ -135,32 +138,35 @@ func returnType(funcType reflect.Type) (types.ServiceType, bool, error) {
 		errorTypeIndex   = 1
 	)
 	if numReturnValues == 1 {
-		serviceType := funcType.Out(serviceTypeIndex)
-		return types.ServiceTypeFrom(serviceType), false, nil
+		serviceType, err := types.ServiceTypeFrom(funcType.Out(serviceTypeIndex))
+		return serviceType, false, err
 	}
 	if numReturnValues == 2 {
-		serviceType := funcType.Out(serviceTypeIndex)
 		errorType := funcType.Out(errorTypeIndex)
 		if isErrorType(errorType) {
-			return types.ServiceTypeFrom(serviceType), true, nil
+			serviceType, err := types.ServiceTypeFrom(funcType.Out(serviceTypeIndex))
+			return serviceType, true, err
 		}
 		return nil, false, types.NewReflectionError(ErrorSecondReturnTypeIsNotErr)
 	}
 	return nil, false, types.NewReflectionError(ErrorReturnTypeHasToHaveExactlyOnReturnValue)
 }
 
-func parameterInfos(funcType reflect.Type) []types.FunctionParameterInfo {
+func parameterInfos(funcType reflect.Type) ([]types.FunctionParameterInfo, error) {
 	parameters := make([]types.FunctionParameterInfo, 0)
 	numParameters := funcType.NumIn()
 	for i := 0; i < numParameters; i++ {
 		parameterType := funcType.In(i)
-		serviceType := types.ServiceTypeFrom(parameterType)
+		serviceType, err := types.ServiceTypeFrom(parameterType)
+		if err != nil {
+			return nil, err
+		}
 		p := functionParameterInfo{
 			parameterType: serviceType,
 		}
 		parameters = append(parameters, p)
 	}
-	return parameters
+	return parameters, nil
 }
 
 func isErrorType(t reflect.Type) bool {
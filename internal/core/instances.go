@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"sync"
 
 	"github.com/matzefriedrich/parsley/pkg/types"
 )
@@ -18,6 +19,113 @@ const (
 	ParsleyContext ContextKey = "__parsley"
 )
 
+// ScopedInstances holds the instances of scoped services created within a service scope, in the order of their creation. It is shared by the
+// resolver and by generated containers, which resolve scoped services with resolving.ResolveScoped.
+type ScopedInstances struct {
+	m         sync.Mutex
+	instances map[any]*scopedInstance
+	order     []*scopedInstance
+}
+
+type scopedInstance struct {
+	m        sync.Mutex
+	instance any
+	created  bool
+}
+
+// ScopedServiceKey identifies the scoped instance of a service by its service type and activator function, so that the resolver and
+// generated containers resolve the same instance of a service within a scope.
+type ScopedServiceKey struct {
+	ServiceType types.ServiceKey
+	Activator   uintptr
+}
+
+// NewScopedInstances creates an empty ScopedInstances object.
+func NewScopedInstances() *ScopedInstances {
+	return &ScopedInstances{
+		instances: make(map[any]*scopedInstance),
+		order:     make([]*scopedInstance, 0),
+	}
+}
+
+func (s *ScopedInstances) entry(key any) *scopedInstance {
+	s.m.Lock()
+	defer s.m.Unlock()
+	e, found := s.instances[key]
+	if !found {
+		e = &scopedInstance{}
+		s.instances[key] = e
+	}
+	return e
+}
+
+func (s *ScopedInstances) created(e *scopedInstance) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.order = append(s.order, e)
+}
+
+func (s *ScopedInstances) get(key any) (interface{}, bool) {
+	s.m.Lock()
+	e, found := s.instances[key]
+	s.m.Unlock()
+	if !found {
+		return nil, false
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	return e.instance, e.created
+}
+
+func (s *ScopedInstances) keep(key any, instance interface{}) {
+	e := s.entry(key)
+	e.m.Lock()
+	defer e.m.Unlock()
+	if !e.created {
+		s.created(e)
+	}
+	e.instance, e.created = instance, true
+}
+
+// GetOrCreate returns the instance stored under the given key, or creates and stores it using the given activator function. Concurrent
+// calls for the same key wait for the instance to be created, so that the activator function is called once.
+func (s *ScopedInstances) GetOrCreate(key any, activatorFunc func() (any, error)) (any, error) {
+	e := s.entry(key)
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.created {
+		return e.instance, nil
+	}
+	instance, err := activatorFunc()
+	if err != nil {
+		return nil, err
+	}
+	e.instance, e.created = instance, true
+	s.created(e)
+	return instance, nil
+}
+
+// Release removes all instances from the scope and returns them in the order of their creation.
+func (s *ScopedInstances) Release() []interface{} {
+	s.m.Lock()
+	defer s.m.Unlock()
+	released := make([]interface{}, 0, len(s.order))
+	for _, e := range s.order {
+		released = append(released, e.instance)
+	}
+	s.instances = make(map[any]*scopedInstance)
+	s.order = make([]*scopedInstance, 0)
+	return released
+}
+
+// scopedInstanceKey returns the key of the scoped instance of the given registration; registrations that do not provide a ScopedServiceKey are identified by their id.
+func scopedInstanceKey(registration types.ServiceRegistration) any {
+	if keyed, ok := registration.(interface{ ScopedInstanceKey() any }); ok {
+		return keyed.ScopedInstanceKey()
+	}
+	return registration.Id()
+}
+
 // NewGlobalInstanceBag Creates a new InstanceBag object with global scope.
 func NewGlobalInstanceBag() *InstanceBag {
 	return &InstanceBag{
@@ -46,9 +154,9 @@ func (b *InstanceBag) TryResolveInstance(ctx context.Context, registration types
 	if found {
 		return instance, true
 	}
-	scopedInstances, hasParsleyContext := ctx.Value(ParsleyContext).(map[uint64]interface{})
+	scopedInstances, hasParsleyContext := ctx.Value(ParsleyContext).(*ScopedInstances)
 	if hasParsleyContext {
-		instance, found = scopedInstances[id]
+		instance, found = scopedInstances.get(scopedInstanceKey(registration))
 		if found {
 			return instance, true
 		}
@@ -71,9 +179,9 @@ func (b *InstanceBag) KeepInstance(ctx context.Context, registration types.Servi
 			}
 		}
 	case types.LifetimeScoped:
-		scopedInstances, hasParsleyContext := ctx.Value(ParsleyContext).(map[uint64]interface{})
+		scopedInstances, hasParsleyContext := ctx.Value(ParsleyContext).(*ScopedInstances)
 		if hasParsleyContext {
-			scopedInstances[id] = instance
+			scopedInstances.keep(scopedInstanceKey(registration), instance)
 		}
 	case types.LifetimeTransient:
 		fallthrough
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/matzefriedrich/parsley/internal/core"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// ValidateActivatorSignature statically checks whether the given function can be registered as an activator function.
+// The rules match the ones applied by registration.CreateServiceRegistration at runtime: the function must return exactly one
+// service value, optionally followed by an error; the service type must be a pointer, interface, function, or struct type, and
+// parameters may additionally be slices of service types. The given value types are the names of types declared in the package
+// that are not supported as service types, for instance, named basic types; see reflection.Model.ValueTypes.
+func ValidateActivatorSignature(f reflection.Function, valueTypes []string) error {
+
+	invalid := func(msg string) error {
+		cause := fmt.Errorf("%s: %w", f.Name, types.NewReflectionError(msg))
+		return newGeneratorError(ErrorInvalidActivatorFunction, types.WithCause(cause))
+	}
+
+	switch len(f.Results) {
+	case 1:
+	case 2:
+		if !isErrorType(f.Results[1]) {
+			return invalid(core.ErrorSecondReturnTypeIsNotErr)
+		}
+	default:
+		return invalid(core.ErrorReturnTypeHasToHaveExactlyOnReturnValue)
+	}
+
+	if !isServiceType(f.Results[0], valueTypes) || f.Results[0].Type.IsArray {
+		return invalid(types.ErrorActivatorFunctionInvalidReturnType)
+	}
+
+	for i, parameter := range f.Parameters {
+		if i == 0 && IsContextParameter(parameter) {
+			continue
+		}
+		if !isServiceType(parameter, valueTypes) {
+			return invalid(fmt.Sprintf("unsupported parameter type %s", FormatType(parameter)))
+		}
+	}
+
+	return nil
+}
+
+// IsContextParameter checks whether the given parameter is of type context.Context.
+func IsContextParameter(p reflection.Parameter) bool {
+	t := p.Type
+	return t != nil && t.Next == nil && !t.IsPointer && !t.IsArray && t.SelectorName == "context" && t.Name == "Context"
+}
+
+func isErrorType(p reflection.Parameter) bool {
+	t := p.Type
+	return t != nil && t.Next == nil && !t.IsPointer && !t.IsArray && t.SelectorName == "" && t.Name == "error"
+}
+
+// isServiceType checks whether the given parameter is of a supported service type. The kind of types declared in other packages is unknown; they are considered supported.
+func isServiceType(p reflection.Parameter, valueTypes []string) bool {
+	t := p.Type
+	if t == nil || t.IsEllipsis {
+		return false
+	}
+	if t.IsPointer || t.IsArray || t.IsInterface {
+		return true
+	}
+	if t.SelectorName == "" && (reflection.IsBuiltinTypeName(t.Name) || slices.Contains(valueTypes, t.Name)) {
+		return false
+	}
+	return true
+}
@@ -1,18 +1,29 @@
 package generator
 
 import (
-	"github.com/matzefriedrich/parsley/internal/templates"
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"path"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/templates"
+	"github.com/matzefriedrich/parsley/pkg/types"
 )
 
+// DefaultProjectTemplate is the name of the project template used if no template is specified.
+const DefaultProjectTemplate = "cli"
+
 type bootstrapGenerator struct {
 	writerFunc ScaffoldingFileWriterFunc
+	options    BootstrapGeneratorOptions
 }
 
 // BootstrapGenerator provides functionalities to scaffold project files.
 type BootstrapGenerator interface {
-	ScaffoldProjectFiles()
+	ScaffoldProjectFiles() ([]ScaffoldedFile, error)
 }
 
 var _ BootstrapGenerator = (*bootstrapGenerator)(nil)
@@ -24,12 +35,163 @@ type projectTemplateModel struct {
 type ProjectItem struct {
 	TemplateName   string
 	TargetFilename string
+	// GenerateMocks generates mocks for the interfaces of the scaffolded file, as the go:generate directive of the file would do.
+	GenerateMocks bool
+}
+
+// ProjectTemplate represents a named set of templates used to scaffold a project.
+type ProjectTemplate struct {
+	Name        string
+	Description string
+	Items       []ProjectItem
+}
+
+// ScaffoldedFile represents a file written, or to be written, by the BootstrapGenerator.
+type ScaffoldedFile struct {
+	Filename string
+	// Exists is true if the file already exists in the project folder and gets overwritten.
+	Exists bool
 }
 
 type ScaffoldingFileWriterFunc func(targetFilename string) (io.WriteCloser, error)
 
-// ScaffoldProjectFiles generates required project files using predefined templates and saves them to the project folder
-func (b *bootstrapGenerator) ScaffoldProjectFiles() {
+// ScaffoldingFileExistsFunc reports whether the given file already exists in the project folder.
+type ScaffoldingFileExistsFunc func(targetFilename string) (bool, error)
+
+// BootstrapGeneratorOptions holds the options of the BootstrapGenerator.
+type BootstrapGeneratorOptions struct {
+	// Template is the name of the project template; defaults to DefaultProjectTemplate.
+	Template string
+	// Force overwrites existing project files; otherwise, the scaffolding fails without writing any file if a project file already exists.
+	Force bool
+	// DryRun renders all project files and reports the files that would be written, without writing them.
+	DryRun bool
+	// FileExists is used to detect existing project files; if not set, all files are considered new.
+	FileExists ScaffoldingFileExistsFunc
+	// GeneratorVersion is the version of the Parsley CLI recorded in the header of generated mocks.
+	GeneratorVersion string
+}
+
+type BootstrapGeneratorOptionsFunc func(config *BootstrapGeneratorOptions)
+
+var projectTemplates = []ProjectTemplate{
+	{
+		Name:        "cli",
+		Description: "A command-line application that resolves a command within a new scope for each invocation",
+		Items: []ProjectItem{
+			{TemplateName: "cli/application.gotmpl", TargetFilename: "application.go"},
+			{TemplateName: "cli/main.gotmpl", TargetFilename: "main.go"},
+			{TemplateName: "shared/greeter.gotmpl", TargetFilename: "greeter.go", GenerateMocks: true},
+			{TemplateName: "cli/greet_command.gotmpl", TargetFilename: "greet_command.go"},
+			{TemplateName: "cli/greet_command_test.gotmpl", TargetFilename: "greet_command_test.go"},
+		},
+	},
+	{
+		Name:        "http-server",
+		Description: "An HTTP server that resolves request handlers within a new scope for each request",
+		Items: []ProjectItem{
+			{TemplateName: "http-server/application.gotmpl", TargetFilename: "application.go"},
+			{TemplateName: "http-server/main.gotmpl", TargetFilename: "main.go"},
+			{TemplateName: "shared/greeter.gotmpl", TargetFilename: "greeter.go", GenerateMocks: true},
+			{TemplateName: "http-server/hello_handler.gotmpl", TargetFilename: "hello_handler.go"},
+			{TemplateName: "http-server/hello_handler_test.gotmpl", TargetFilename: "hello_handler_test.go"},
+		},
+	},
+	{
+		Name:        "worker",
+		Description: "A background worker that resolves a job handler within a new scope for each job",
+		Items: []ProjectItem{
+			{TemplateName: "worker/application.gotmpl", TargetFilename: "application.go"},
+			{TemplateName: "worker/main.gotmpl", TargetFilename: "main.go"},
+			{TemplateName: "shared/greeter.gotmpl", TargetFilename: "greeter.go", GenerateMocks: true},
+			{TemplateName: "worker/job_handler.gotmpl", TargetFilename: "job_handler.go"},
+			{TemplateName: "worker/job_handler_test.gotmpl", TargetFilename: "job_handler_test.go"},
+		},
+	},
+}
+
+// ProjectTemplates returns the project templates supported by the BootstrapGenerator.
+func ProjectTemplates() []ProjectTemplate {
+	return projectTemplates
+}
+
+// ProjectTemplateNames returns the names of the supported project templates.
+func ProjectTemplateNames() []string {
+	names := make([]string, 0, len(projectTemplates))
+	for _, t := range projectTemplates {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+type scaffoldedFileContent struct {
+	ScaffoldedFile
+	code []byte
+}
+
+// ScaffoldProjectFiles renders the files of the selected project template and saves them to the project folder. All files are rendered, and checked for existence, before the first file is written.
+func (b *bootstrapGenerator) ScaffoldProjectFiles() ([]ScaffoldedFile, error) {
+
+	projectTemplate, err := b.projectTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := b.renderProjectFiles(projectTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	existingFiles := make([]string, 0)
+	for i, file := range files {
+		if b.options.FileExists == nil {
+			continue
+		}
+		exists, existsErr := b.options.FileExists(file.Filename)
+		if existsErr != nil {
+			return nil, existsErr
+		}
+		files[i].Exists = exists
+		if exists {
+			existingFiles = append(existingFiles, file.Filename)
+		}
+	}
+
+	result := make([]ScaffoldedFile, 0, len(files))
+	for _, file := range files {
+		result = append(result, file.ScaffoldedFile)
+	}
+
+	if len(existingFiles) > 0 && !b.options.Force {
+		return result, newGeneratorError(ErrorProjectFilesAlreadyExist, types.WithCause(errors.New(strings.Join(existingFiles, ", "))))
+	}
+
+	if b.options.DryRun {
+		return result, nil
+	}
+
+	var errs []error
+	for _, file := range files {
+		err := b.writeFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate %s: %w", file.Filename, err))
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (b *bootstrapGenerator) projectTemplate() (ProjectTemplate, error) {
+	for _, t := range projectTemplates {
+		if t.Name == b.options.Template {
+			return t, nil
+		}
+	}
+	cause := fmt.Errorf("%q; supported templates are: %s", b.options.Template, strings.Join(ProjectTemplateNames(), ", "))
+	return ProjectTemplate{}, newGeneratorError(ErrorUnknownProjectTemplate, types.WithCause(cause))
+}
+
+func (b *bootstrapGenerator) renderProjectFiles(projectTemplate ProjectTemplate) ([]scaffoldedFileContent, error) {
 
 	gen := NewGenericCodeGenerator(func(name string) (string, error) {
 		templateFilePath := path.Join("bootstrap", name)
@@ -42,33 +204,89 @@ func (b *bootstrapGenerator) ScaffoldProjectFiles() {
 
 	m := &projectTemplateModel{}
 
-	projectTemplateFiles := []ProjectItem{
-		{TemplateName: "application.gotmpl", TargetFilename: "application.go"},
-		{TemplateName: "main.gotmpl", TargetFilename: "main.go"},
-		{TemplateName: "greeter.gotmpl", TargetFilename: "greeter.go"},
-	}
-
-	for _, projectItem := range projectTemplateFiles {
-		generateFile := func(item ProjectItem) error {
-			f, err := b.writerFunc(item.TargetFilename)
+	files := make([]scaffoldedFileContent, 0, len(projectTemplate.Items))
+	for _, item := range projectTemplate.Items {
+		var code bytes.Buffer
+		err := gen.Generate(item.TemplateName, m, &code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", item.TargetFilename, err)
+		}
+		files = append(files, scaffoldedFileContent{ScaffoldedFile: ScaffoldedFile{Filename: item.TargetFilename}, code: code.Bytes()})
+		if item.GenerateMocks {
+			mocks, err := generateMocksFor(item.TargetFilename, code.Bytes(), b.options.GeneratorVersion)
 			if err != nil {
-				return err
+				return nil, fmt.Errorf("failed to generate mocks for %s: %w", item.TargetFilename, err)
 			}
-			defer func(f io.WriteCloser) {
-				_ = f.Close()
-			}(f)
-			return gen.Generate(item.TemplateName, m, f)
+			files = append(files, mocks)
 		}
-		err := generateFile(projectItem)
-		if err != nil {
-			continue
+	}
+
+	return files, nil
+}
+
+// generateMocksFor generates the mocks for the given scaffolded source file; the result equals the output of the parsley-cli generate mocks command.
+func generateMocksFor(filename string, code []byte, generatorVersion string) (scaffoldedFileContent, error) {
+
+	const kind = "mocks"
+	var generatedCode bytes.Buffer
+
+	gen, err := NewCodeFileGenerator(kind, reflection.AstFromSource(code), func(config *CodeFileGeneratorOptions) {
+		config.TemplateLoader = func(_ string) (string, error) {
+			return templates.MockTemplate, nil
+		}
+		config.ConfigureModelCallback = func(m *reflection.Model) {
+			m.AddImport("github.com/matzefriedrich/parsley/pkg/features")
+		}
+		config.GeneratorVersion = generatorVersion
+		config.OutputWriterFactory = func(_ string, _ *reflection.AstFileSource) (io.WriteCloser, error) {
+			return nopWriteCloser{Writer: &generatedCode}, nil
 		}
+	})
+	if err != nil {
+		return scaffoldedFileContent{}, err
+	}
+
+	err = gen.GenerateCode()
+	if err != nil {
+		return scaffoldedFileContent{}, err
+	}
+
+	return scaffoldedFileContent{
+		ScaffoldedFile: ScaffoldedFile{Filename: GeneratedFilePath(kind, filename)},
+		code:           generatedCode.Bytes(),
+	}, nil
+}
+
+func (b *bootstrapGenerator) writeFile(file scaffoldedFileContent) error {
+	f, err := b.writerFunc(file.Filename)
+	if err != nil {
+		return err
 	}
+	defer func(f io.WriteCloser) {
+		_ = f.Close()
+	}(f)
+	_, err = f.Write(file.code)
+	return err
+}
+
+type nopWriteCloser struct {
+	io.Writer
 }
 
-// NewBootstrapGenerator initializes and returns a BootstrapGenerator with the specified project folder path.
-func NewBootstrapGenerator(writerFunc ScaffoldingFileWriterFunc) BootstrapGenerator {
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// NewBootstrapGenerator initializes and returns a BootstrapGenerator that writes project files using the specified writer function.
+func NewBootstrapGenerator(writerFunc ScaffoldingFileWriterFunc, config ...BootstrapGeneratorOptionsFunc) BootstrapGenerator {
+	options := BootstrapGeneratorOptions{
+		Template: DefaultProjectTemplate,
+	}
+	for _, f := range config {
+		f(&options)
+	}
 	return &bootstrapGenerator{
 		writerFunc: writerFunc,
+		options:    options,
 	}
 }
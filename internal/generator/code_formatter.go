@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// FormatSource formats the given generated code with go/format. If the code cannot be parsed, the returned error reports the offending line of the generated code.
+func FormatSource(code []byte) ([]byte, error) {
+	formattedCode, err := format.Source(code)
+	if err != nil {
+		return nil, newGeneratorError(ErrorCannotFormatGeneratedCode, types.WithCause(generatedCodeError(code, err)))
+	}
+	return formattedCode, nil
+}
+
+// OrganizeImports rewrites the import declarations of the given generated code, so that only packages that are referenced by the code get imported.
+// Package names are resolved against the given known imports first, and then against the imports declared in the code. An alias is added
+// if a package is referenced by a name other than its assumed package name. Blank and dot imports are always kept. Standard library packages are grouped before other packages.
+func OrganizeImports(code []byte, knownImports ...ImportModel) ([]byte, error) {
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, newGeneratorError(ErrorCannotFormatGeneratedCode, types.WithCause(generatedCodeError(code, err)))
+	}
+
+	candidates := make([]ImportModel, 0, len(knownImports)+len(file.Imports))
+	imports := make([]ImportModel, 0)
+	addCandidate := func(candidate ImportModel) {
+		if candidate.Alias == "_" || candidate.Alias == "." {
+			// keep imports for side effects, and dot imports, since references to their identifiers are not qualified by a package name
+			imports = append(imports, candidate)
+			return
+		}
+		candidates = append(candidates, candidate)
+	}
+	for _, knownImport := range knownImports {
+		addCandidate(knownImport)
+	}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		candidate := ImportModel{Path: importPath}
+		if spec.Name != nil {
+			candidate.Alias = spec.Name.Name
+		}
+		addCandidate(candidate)
+	}
+
+	for _, name := range referencedPackageNames(file) {
+		index := slices.IndexFunc(candidates, func(candidate ImportModel) bool {
+			return candidate.Name() == name
+		})
+		if index < 0 {
+			continue // not a package reference, for instance, a type declared in another file of the package
+		}
+		imports = append(imports, ImportModel{Alias: name, Path: candidates[index].Path})
+	}
+
+	// Remove all import declarations, and insert the organized import block after the package clause
+	buffer := bytes.Buffer{}
+	packageClauseEnd := fileSet.Position(file.Name.End()).Offset
+	buffer.Write(code[:packageClauseEnd])
+	buffer.WriteString("\n\n")
+	buffer.WriteString(importBlock(imports))
+	offset := packageClauseEnd
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		buffer.Write(code[offset:fileSet.Position(genDecl.Pos()).Offset])
+		offset = fileSet.Position(genDecl.End()).Offset
+	}
+	buffer.Write(code[offset:])
+
+	return FormatSource(buffer.Bytes())
+}
+
+// Name returns the name under which the imported package is referenced.
+func (i ImportModel) Name() string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+	return reflection.PackageNameFromImportPath(i.Path)
+}
+
+// referencedPackageNames returns the sorted names of all unresolved identifiers used as the operand of a selector expression, which are package references.
+func referencedPackageNames(file *ast.File) []string {
+	names := make([]string, 0)
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		//nolint:staticcheck // The object resolution of the parser is sufficient to distinguish package references from local identifiers
+		if ident, isIdent := selector.X.(*ast.Ident); isIdent && ident.Obj == nil {
+			names = append(names, ident.Name)
+		}
+		return true
+	})
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func importBlock(imports []ImportModel) string {
+
+	slices.SortFunc(imports, func(a, b ImportModel) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	imports = slices.Compact(imports)
+	if len(imports) == 0 {
+		return ""
+	}
+
+	isStandardLibrary := func(i ImportModel) bool {
+		firstElement, _, _ := strings.Cut(i.Path, "/")
+		return !strings.Contains(firstElement, ".")
+	}
+
+	buffer := strings.Builder{}
+	buffer.WriteString("import (\n")
+	for _, group := range [][]ImportModel{
+		slices.DeleteFunc(slices.Clone(imports), func(i ImportModel) bool { return !isStandardLibrary(i) }),
+		slices.DeleteFunc(slices.Clone(imports), isStandardLibrary),
+	} {
+		if len(group) == 0 {
+			continue
+		}
+		if buffer.Len() > len("import (\n") {
+			buffer.WriteString("\n")
+		}
+		for _, i := range group {
+			buffer.WriteString("\t")
+			buffer.WriteString(i.String())
+			buffer.WriteString("\n")
+		}
+	}
+	buffer.WriteString(")\n")
+	return buffer.String()
+}
+
+// generatedCodeError extends the given syntax error with the offending line of the generated code.
+func generatedCodeError(code []byte, err error) error {
+	var errorList scanner.ErrorList
+	if !errors.As(err, &errorList) || len(errorList) == 0 {
+		return err
+	}
+	first := errorList[0]
+	lines := strings.Split(string(code), "\n")
+	if first.Pos.Line < 1 || first.Pos.Line > len(lines) {
+		return err
+	}
+	offendingLine := strings.TrimRight(lines[first.Pos.Line-1], " \t\r")
+	return fmt.Errorf("generated code line %d:%d: %s\n%5d | %s", first.Pos.Line, first.Pos.Column, first.Msg, first.Pos.Line, offendingLine)
+}
@@ -0,0 +1,250 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const (
+	constructorTagKey           = "parsley"
+	constructorTagExcluded      = "-"
+	constructorTagOptional      = "optional"
+	constructorTagNamePrefix    = "name="
+	constructorContextParameter = "ctx"
+	constructorResolverParam    = "resolver"
+	constructorErrorVariable    = "err"
+)
+
+// ConstructorTemplateModel is the template model of generated constructor functions for struct types.
+type ConstructorTemplateModel struct {
+	PackageName  string
+	Imports      []ImportModel
+	Packages     ConstructorPackageNames
+	Constructors []ConstructorModel
+}
+
+// ConstructorPackageNames holds the names under which the generated code references the packages used by constructor functions.
+type ConstructorPackageNames struct {
+	Context   string
+	Errors    string
+	Resolving string
+	Types     string
+}
+
+// ConstructorModel describes a generated constructor function that is a valid activator function for a struct type.
+type ConstructorModel struct {
+	Name           string
+	TypeName       string
+	HasContext     bool
+	HasResolver    bool
+	HasErrorReturn bool
+	Parameters     []ConstructorParameterModel
+	Fields         []ConstructorFieldModel
+}
+
+// ConstructorParameterModel describes a parameter of a generated constructor function, except the context and resolver parameters.
+type ConstructorParameterModel struct {
+	Name string
+	Type string
+}
+
+// ConstructorFieldModel describes a field initialized by a generated constructor function.
+type ConstructorFieldModel struct {
+	Name string
+	// Variable is the name of the parameter or local variable holding the value of the field.
+	Variable string
+	Type     string
+	// ServiceName is the name of a named dependency; the value is obtained from the named service resolver function passed as the Resolver parameter.
+	ServiceName string
+	Resolver    string
+	// Optional is set if the field keeps its zero value if the dependency cannot be resolved.
+	Optional bool
+}
+
+// IsParameter returns true if the field value is passed directly as a parameter of the constructor function.
+func (f ConstructorFieldModel) IsParameter() bool {
+	return f.ServiceName == "" && !f.Optional
+}
+
+// ConstructorOptions configures the generation of constructor functions.
+type ConstructorOptions struct {
+	// TypeNames holds the names of the struct types to generate constructors for.
+	TypeNames []string
+	// Context adds a context.Context parameter to all constructors; the parameter is always added if a constructor resolves optional dependencies.
+	Context bool
+	// Error adds an error result to all constructors; the result is always added if the resolution of a dependency can fail.
+	Error bool
+}
+
+// ConstructorNameFrom derives the name of the constructor function of the given type, for instance, NewGreeter for greeter.
+func ConstructorNameFrom(typeName string) string {
+	return "New" + MakePublic(typeName)
+}
+
+// NewConstructorTemplateModel creates the template model of constructor functions for the given struct types declared in the given source file.
+// Each field becomes a parameter of the constructor, unless the field tag says otherwise: `parsley:"-"` excludes a field, `parsley:"optional"`
+// resolves the dependency from the resolver and keeps the zero value if the service type is not registered, and `parsley:"name=<name>"` obtains
+// the named service from the resolver function registered by features.RegisterNamed. Options can be combined, for instance, `parsley:"optional,name=redis"`.
+func NewConstructorTemplateModel(source *reflection.AstFileSource, model *reflection.Model, options ConstructorOptions) (*ConstructorTemplateModel, error) {
+
+	if len(options.TypeNames) == 0 {
+		return nil, newConstructorError("no type name specified")
+	}
+
+	imports := importsOf(source.File)
+	result := &ConstructorTemplateModel{
+		PackageName:  model.PackageName,
+		Imports:      make([]ImportModel, 0),
+		Constructors: make([]ConstructorModel, 0, len(options.TypeNames)),
+	}
+
+	packageName := func(importPath string) string {
+		name := packageNameFor(imports, importPath)
+		result.Imports = append(result.Imports, ImportModel{Alias: name, Path: importPath})
+		return name
+	}
+	result.Packages = ConstructorPackageNames{
+		Context:   packageName("context"),
+		Errors:    packageName("errors"),
+		Resolving: packageName(parsleyResolvingPackage),
+		Types:     packageName(parsleyTypesPackage),
+	}
+	for name, importPath := range imports {
+		result.Imports = append(result.Imports, ImportModel{Alias: name, Path: importPath})
+	}
+	slices.SortFunc(result.Imports, func(a, b ImportModel) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	for _, typeName := range options.TypeNames {
+		index := slices.IndexFunc(model.Structs, func(s reflection.Struct) bool {
+			return s.Name == typeName
+		})
+		if index < 0 {
+			return nil, newConstructorError(fmt.Sprintf("struct type %s not found in %s", typeName, source.Filename))
+		}
+		constructor, err := newConstructorModel(source, model.Structs[index], result.Packages, options)
+		if err != nil {
+			return nil, err
+		}
+		result.Constructors = append(result.Constructors, constructor)
+	}
+
+	return result, nil
+}
+
+func newConstructorModel(source *reflection.AstFileSource, s reflection.Struct, packages ConstructorPackageNames, options ConstructorOptions) (ConstructorModel, error) {
+
+	if s.Generic {
+		return ConstructorModel{}, newConstructorError(fmt.Sprintf("%s is a generic type; generic types are not supported", s.Name))
+	}
+
+	constructor := ConstructorModel{
+		Name:           ConstructorNameFrom(s.Name),
+		TypeName:       s.Name,
+		HasContext:     options.Context,
+		HasErrorReturn: options.Error,
+		Parameters:     make([]ConstructorParameterModel, 0, len(s.Fields)),
+		Fields:         make([]ConstructorFieldModel, 0, len(s.Fields)),
+	}
+
+	// Parameters and local variables must not shadow the packages referenced by the constructor body
+	variables := newVariableNames(constructorContextParameter, constructorResolverParam, constructorErrorVariable, packages.Errors, packages.Resolving, packages.Types)
+	for _, f := range s.Fields {
+		excluded, optional, serviceName, err := parseConstructorTag(s.Name, f)
+		if err != nil {
+			return ConstructorModel{}, err
+		}
+		if excluded {
+			continue
+		}
+		field := ConstructorFieldModel{
+			Name:        f.Name,
+			Variable:    variables.add(MakePrivate(f.Name)),
+			Type:        source.Text(f.Type),
+			ServiceName: serviceName,
+			Optional:    optional,
+		}
+		switch {
+		case field.IsParameter():
+			constructor.Parameters = append(constructor.Parameters, ConstructorParameterModel{Name: field.Variable, Type: field.Type})
+		case field.ServiceName != "":
+			field.Resolver = variables.add(field.Variable + "Resolver")
+			constructor.Parameters = append(constructor.Parameters, ConstructorParameterModel{Name: field.Resolver, Type: fmt.Sprintf("func(string) (%s, error)", field.Type)})
+			constructor.HasErrorReturn = constructor.HasErrorReturn || !field.Optional
+		default:
+			constructor.HasContext = true
+			constructor.HasResolver = true
+			constructor.HasErrorReturn = true
+		}
+		constructor.Fields = append(constructor.Fields, field)
+	}
+
+	return constructor, nil
+}
+
+func parseConstructorTag(typeName string, field reflection.Field) (excluded bool, optional bool, serviceName string, err error) {
+	tag, found := reflect.StructTag(field.Tag).Lookup(constructorTagKey)
+	if !found {
+		return false, false, "", nil
+	}
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == constructorTagExcluded:
+			excluded = true
+		case option == constructorTagOptional:
+			optional = true
+		case strings.HasPrefix(option, constructorTagNamePrefix) && len(option) > len(constructorTagNamePrefix):
+			serviceName = strings.TrimPrefix(option, constructorTagNamePrefix)
+		default:
+			return false, false, "", newConstructorError(fmt.Sprintf("invalid tag option %q of field %s.%s", option, typeName, field.Name))
+		}
+	}
+	return excluded, optional, serviceName, nil
+}
+
+// packageNameFor returns the name under which the given package is imported by the source file, or a name not used by any import of the source file.
+func packageNameFor(imports map[string]string, importPath string) string {
+	for name, p := range imports {
+		if p == importPath {
+			return name
+		}
+	}
+	base := reflection.PackageNameFromImportPath(importPath)
+	name := base
+	for n := 1; ; n++ {
+		if _, inUse := imports[name]; !inUse {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, n)
+	}
+}
+
+type variableNames struct {
+	used []string
+}
+
+func newVariableNames(reserved ...string) *variableNames {
+	return &variableNames{used: reserved}
+}
+
+// add returns a unique variable name derived from the given name; keywords and names already in use get a numeric suffix.
+func (v *variableNames) add(name string) string {
+	unique := name
+	for n := 1; token.IsKeyword(unique) || slices.Contains(v.used, unique); n++ {
+		unique = fmt.Sprintf("%s%d", name, n)
+	}
+	v.used = append(v.used, unique)
+	return unique
+}
+
+func newConstructorError(msg string) error {
+	return newGeneratorError(ErrorCannotGenerateConstructor, types.WithCause(fmt.Errorf("%s", msg)))
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+const (
+	generatedCodeHeaderPrefix    = "// Code generated "
+	generatedByParsleyHeader     = generatedCodeHeaderPrefix + "by parsley-cli"
+	generatorVersionHeaderPrefix = "// Parsley CLI version: "
+)
+
+// GeneratorVersionFrom reads the version of the Parsley CLI that generated the given code from its header. Returns an empty string if the code has no version header.
+func GeneratorVersionFrom(code []byte) string {
+	return headerValue(code, generatorVersionHeaderPrefix)
+}
+
+// WithGeneratorVersion adds a header to the given generated code that records the version of the Parsley CLI that generated it.
+func WithGeneratorVersion(code []byte, version string) []byte {
+	return withHeader(code, generatorVersionHeaderPrefix+version)
+}
+
+// IsGeneratedByParsley checks whether the header of the given code marks it as generated by the Parsley CLI.
+func IsGeneratedByParsley(code []byte) bool {
+	firstLine, _, _ := bytes.Cut(code, []byte("\n"))
+	return bytes.HasPrefix(firstLine, []byte(generatedByParsleyHeader))
+}
+
+// headerValue returns the value of the header comment with the given prefix; only comments before the package clause are considered.
+func headerValue(code []byte, prefix string) string {
+	scanner := bufio.NewScanner(bytes.NewReader(code))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if value, found := strings.CutPrefix(line, prefix); found {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// withHeader inserts the given header comment after the "Code generated" comment, or at the top of the code if there is no such comment.
+func withHeader(code []byte, header string) []byte {
+	buffer := bytes.Buffer{}
+	firstLine, rest, found := bytes.Cut(code, []byte("\n"))
+	if found && bytes.HasPrefix(firstLine, []byte(generatedCodeHeaderPrefix)) {
+		buffer.Write(firstLine)
+		buffer.WriteString("\n")
+		buffer.WriteString(header + "\n")
+		buffer.Write(rest)
+		return buffer.Bytes()
+	}
+	buffer.WriteString(header + "\n")
+	buffer.WriteString("\n")
+	buffer.Write(code)
+	return buffer.Bytes()
+}
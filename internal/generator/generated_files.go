@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GeneratedFile describes a code file generated by the Parsley CLI.
+type GeneratedFile struct {
+	Path string
+	// GeneratorVersion is the version of the Parsley CLI recorded in the file header; empty for files generated by versions that did not record it.
+	GeneratorVersion string
+}
+
+// maxHeaderSize limits how much of a file is read to find its generated code header.
+const maxHeaderSize = 4096
+
+// FindGeneratedFiles returns the files generated by the Parsley CLI in the given directory, or below it, if recursive is set. Only *.g.go files are considered; vendor and testdata directories, as well as directories starting with a dot or underscore, are skipped.
+func FindGeneratedFiles(dir string, recursive bool) ([]GeneratedFile, error) {
+
+	files := make([]GeneratedFile, 0)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if filePath == dir {
+				return nil
+			}
+			if !recursive || name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".g.go") {
+			return nil
+		}
+		header, readErr := readHeader(filePath)
+		if readErr != nil {
+			return readErr
+		}
+		if !IsGeneratedByParsley(header) {
+			return nil
+		}
+		files = append(files, GeneratedFile{
+			Path:             filePath,
+			GeneratorVersion: GeneratorVersionFrom(header),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func readHeader(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	buffer := bytes.Buffer{}
+	_, err = io.CopyN(&buffer, f, maxHeaderSize)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
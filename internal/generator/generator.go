@@ -1,8 +1,12 @@
 package generator
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/utils"
+	"github.com/matzefriedrich/parsley/pkg/types"
 	"io"
 	"os"
 	"path"
@@ -18,31 +22,93 @@ type codeFileGenerator struct {
 type CodeFileGeneratorOptions struct {
 	TemplateLoader         TemplateLoader
 	ConfigureModelCallback reflection.ModelConfigurationFunc
+	TemplateModelFactory   TemplateModelFactory
 	OutputWriterFactory    OutputWriterFactory
-	kind                   string
+	// OutputReaderFactory reads previously generated code. If set, generation is skipped if the source hash of the existing code matches the current inputs.
+	OutputReaderFactory OutputReaderFactory
+	// Check renders the code into memory and compares it with the previously generated code instead of writing it. Returns an error with a unified diff if the code is outdated.
+	Check bool
+	// HashInputs provides additional inputs for the source hash, for instance, flag values or other source files that affect the generated code.
+	HashInputs func() ([][]byte, error)
+	// TemplateName is passed to the TemplateLoader and used to report template errors; defaults to the kind of the generated code.
+	TemplateName string
+	// ResultCallback is invoked with the outcome of the generation, unless generation fails before the code has been compared or written.
+	ResultCallback func(result GenerationResult)
+	// GeneratorVersion is the version of the Parsley CLI; if set, it is recorded in the header of the generated code and is part of the source hash.
+	GeneratorVersion string
+	kind             string
 }
 
+// GenerationStatus describes the outcome of generating a code file.
+type GenerationStatus string
+
+const (
+	// Generated indicates that the code has been written to the output file.
+	Generated GenerationStatus = "generated"
+	// Unchanged indicates that generation has been skipped, because the source hash of the existing code matches the current inputs.
+	Unchanged GenerationStatus = "unchanged"
+	// UpToDate indicates that the existing code matches the generated code in check mode.
+	UpToDate GenerationStatus = "up-to-date"
+	// Outdated indicates that the existing code differs from the generated code in check mode.
+	Outdated GenerationStatus = "outdated"
+)
+
+// GenerationResult describes the outcome of generating the code of a specific kind for a source file.
+type GenerationResult struct {
+	Kind   string
+	Path   string
+	Status GenerationStatus
+}
+
+// TemplateModelFactory creates a custom template model from the reflected source model. Use it if a template requires a model other than reflection.Model.
+type TemplateModelFactory func(source *reflection.AstFileSource, m *reflection.Model) (any, error)
+
 type CodeFileGenerator interface {
 	GenerateCode() error
 }
 
 type CodeFileGeneratorOptionsFunc func(config *CodeFileGeneratorOptions)
 
+// GeneratedFilePath returns the path of the file that contains the code of the given kind generated for the specified source file, for instance, greeter.mocks.g.go.
+func GeneratedFilePath(kind string, sourceFilename string) string {
+	fileName := path.Base(sourceFilename)
+	fileNameWithoutExtension := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	fileDirectory := path.Dir(sourceFilename)
+	return path.Join(fileDirectory, fmt.Sprintf("%s.%s.g.go", fileNameWithoutExtension, kind))
+}
+
+// PackageHashInputs returns the file names and contents of the given package sources as inputs of a source hash. Files generated for the given kind are skipped, since they are the output of the generator, not its input.
+func PackageHashInputs(kind string, sources []*reflection.AstFileSource) [][]byte {
+	generatedFileSuffix := fmt.Sprintf(".%s.g.go", kind)
+	inputs := make([][]byte, 0, 2*len(sources))
+	for _, source := range sources {
+		if strings.HasSuffix(source.Filename, generatedFileSuffix) {
+			continue
+		}
+		inputs = append(inputs, []byte(source.Filename), source.Content)
+	}
+	return inputs
+}
+
 // FileOutputWriter Creates an OutputWriterFactory object that can be used create file writers.
 func FileOutputWriter() OutputWriterFactory {
 	return func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
-		fileName := path.Base(source.Filename)
-		fileNameWithoutExtension := strings.TrimSuffix(fileName, filepath.Ext(fileName))
-		fileDirectory := path.Dir(source.Filename)
-
-		targetFilePath := path.Join(fileDirectory, fmt.Sprintf("%s.%s.g.go", fileNameWithoutExtension, kind))
+		targetFilePath := GeneratedFilePath(kind, source.Filename)
 		return os.OpenFile(targetFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
 	}
 }
 
+// FileOutputReader Creates an OutputReaderFactory object that reads previously generated files written by FileOutputWriter.
+func FileOutputReader() OutputReaderFactory {
+	return func(kind string, source *reflection.AstFileSource) ([]byte, error) {
+		return os.ReadFile(GeneratedFilePath(kind, source.Filename))
+	}
+}
+
 func NewCodeFileGenerator(kind string, fileAccessor reflection.AstFileAccessor, config ...CodeFileGeneratorOptionsFunc) (CodeFileGenerator, error) {
 	options := CodeFileGeneratorOptions{
-		kind: kind,
+		TemplateName: kind,
+		kind:         kind,
 	}
 	for _, f := range config {
 		f(&options)
@@ -58,8 +124,15 @@ func NewCodeFileGenerator(kind string, fileAccessor reflection.AstFileAccessor,
 
 func (g *codeFileGenerator) GenerateCode() error {
 
-	gen := NewGenericCodeGenerator(g.options.TemplateLoader)
-	err := RegisterTemplateFunctions(gen, RegisterTypeModelFunctions, RegisterNamingFunctions)
+	templateText, err := g.options.TemplateLoader(g.options.TemplateName)
+	if err != nil {
+		return newGeneratorError(ErrorCannotGenerateProxies, types.WithCause(err))
+	}
+
+	gen := NewGenericCodeGenerator(func(_ string) (string, error) {
+		return templateText, nil
+	})
+	err = RegisterTemplateFunctions(gen, RegisterTypeModelFunctions, RegisterNamingFunctions)
 	if err != nil {
 		return err
 	}
@@ -69,6 +142,21 @@ func (g *codeFileGenerator) GenerateCode() error {
 		return err
 	}
 
+	sourceHash, err := g.sourceHash(source, templateText)
+	if err != nil {
+		return err
+	}
+
+	existingCode, err := g.readExistingCode(source)
+	if err != nil {
+		return err
+	}
+
+	if !g.options.Check && existingCode != nil && SourceHashFrom(existingCode) == sourceHash {
+		g.reportResult(source, Unchanged)
+		return nil // the inputs did not change since the code has been generated
+	}
+
 	builder := NewTemplateModelBuilder(source.File)
 
 	model, err := builder.Build()
@@ -80,6 +168,41 @@ func (g *codeFileGenerator) GenerateCode() error {
 		g.options.ConfigureModelCallback(model)
 	}
 
+	var templateModel any = model
+	if g.options.TemplateModelFactory != nil {
+		templateModel, err = g.options.TemplateModelFactory(source, model)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Generate the code before the output writer is opened, so that an existing file is not touched if the code cannot be generated
+	var generatedCode bytes.Buffer
+	err = gen.Generate(g.options.TemplateName, templateModel, &generatedCode)
+	if err != nil {
+		return err
+	}
+
+	code, err := OrganizeImports(generatedCode.Bytes(), sourceImportsOf(model)...)
+	if err != nil {
+		return err
+	}
+	code = WithSourceHash(code, sourceHash)
+	if g.options.GeneratorVersion != "" {
+		code = WithGeneratorVersion(code, g.options.GeneratorVersion)
+	}
+
+	if g.options.Check {
+		targetFilePath := GeneratedFilePath(g.options.kind, source.Filename)
+		diff := utils.UnifiedDiff(targetFilePath, targetFilePath, existingCode, code)
+		if diff != "" {
+			g.reportResult(source, Outdated)
+			return newGeneratorError(ErrorGeneratedCodeIsOutdated, types.WithCause(errors.New(diff)))
+		}
+		g.reportResult(source, UpToDate)
+		return nil
+	}
+
 	f, outputErr := g.options.OutputWriterFactory(g.options.kind, source)
 	if outputErr != nil {
 		return outputErr
@@ -89,10 +212,73 @@ func (g *codeFileGenerator) GenerateCode() error {
 		_ = f.Close()
 	}(f)
 
-	err = gen.Generate(g.options.kind, model, f)
-	if err != nil {
-		return err
+	_, writerErr := f.Write(code)
+	if writerErr != nil {
+		return newGeneratorError(ErrorFailedToWriteGeneratedCode, types.WithCause(writerErr))
 	}
 
+	g.reportResult(source, Generated)
+
 	return nil
 }
+
+func (g *codeFileGenerator) reportResult(source *reflection.AstFileSource, status GenerationStatus) {
+	if g.options.ResultCallback == nil {
+		return
+	}
+	g.options.ResultCallback(GenerationResult{
+		Kind:   g.options.kind,
+		Path:   GeneratedFilePath(g.options.kind, source.Filename),
+		Status: status,
+	})
+}
+
+// sourceImportsOf returns the imports of the source file, including their aliases, so that packages referenced by reflected types can be resolved in the generated code.
+func sourceImportsOf(m *reflection.Model) []ImportModel {
+	imports := make([]ImportModel, 0, len(m.Imports))
+	for _, importPath := range m.Imports {
+		imports = append(imports, ImportModel{Alias: m.ImportAliases[importPath], Path: importPath})
+	}
+	return imports
+}
+
+func (g *codeFileGenerator) sourceHash(source *reflection.AstFileSource, templateText string) (string, error) {
+	inputs := [][]byte{[]byte(g.options.kind), []byte(g.options.GeneratorVersion), []byte(templateText), source.Content}
+	if g.options.HashInputs != nil {
+		additionalInputs, err := g.options.HashInputs()
+		if err != nil {
+			return "", err
+		}
+		inputs = append(inputs, additionalInputs...)
+	}
+	return SourceHash(inputs...), nil
+}
+
+// readExistingCode reads the previously generated code. Returns nil if no code has been generated yet, or if no OutputReaderFactory is configured.
+func (g *codeFileGenerator) readExistingCode(source *reflection.AstFileSource) ([]byte, error) {
+	if g.options.OutputReaderFactory == nil {
+		return nil, nil
+	}
+	code, err := g.options.OutputReaderFactory(g.options.kind, source)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return code, nil
+}
+
+// FileTemplateLoader creates a TemplateLoader that reads templates from the file system; the template name is interpreted as the path of the template file.
+func FileTemplateLoader() TemplateLoader {
+	return func(name string) (string, error) {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return "", newGeneratorError(ErrorTemplateFileNotFound, types.WithCause(err))
+			}
+			return "", newGeneratorError(ErrorFailedToOpenTemplateFile, types.WithCause(err))
+		}
+		return string(data), nil
+	}
+}
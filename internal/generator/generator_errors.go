@@ -7,22 +7,40 @@ import (
 
 const (
 	ErrorCannotExecuteTemplate             = "cannot execute template"
+	ErrorCannotParseTemplate               = "cannot parse template"
 	ErrorCannotFormatGeneratedCode         = "cannot format generated code"
 	ErrorCannotGenerateProxies             = "cannot generate proxies"
 	ErrorFailedToOpenTemplateFile          = "failed to open template file"
 	ErrorFailedToWriteGeneratedCode        = "failed to write generated code"
 	ErrorTemplateFileNotFound              = "template file not found"
 	ErrorFailedToObtainGeneratorSourceFile = "failed to obtain generator source file"
+	ErrorInvalidActivatorFunction          = "the annotated function is not a valid activator function"
+	ErrorInvalidDirective                  = "invalid directive"
+	ErrorCannotWireServices                = "cannot wire services"
+	ErrorGeneratedCodeIsOutdated           = "generated code is outdated"
+	ErrorUnknownProjectTemplate            = "unknown project template"
+	ErrorProjectFilesAlreadyExist          = "project files already exist"
+	ErrorCannotExtractInterface            = "cannot extract interface"
+	ErrorCannotGenerateConstructor         = "cannot generate constructor"
 )
 
 var (
 	ErrCannotExecuteTemplate             = errors.New(ErrorCannotExecuteTemplate)
+	ErrCannotParseTemplate               = errors.New(ErrorCannotParseTemplate)
 	ErrCannotFormatGeneratedCode         = errors.New(ErrorCannotFormatGeneratedCode)
 	ErrCannotGenerateProxies             = errors.New(ErrorCannotGenerateProxies)
 	ErrFailedToOpenTemplateFile          = errors.New(ErrorFailedToOpenTemplateFile)
 	ErrFailedToWriteGeneratedCode        = errors.New(ErrorFailedToWriteGeneratedCode)
 	ErrTemplateFileNotFound              = errors.New(ErrorTemplateFileNotFound)
 	ErrFailedToObtainGeneratorSourceFile = errors.New(ErrorFailedToObtainGeneratorSourceFile)
+	ErrInvalidActivatorFunction          = errors.New(ErrorInvalidActivatorFunction)
+	ErrInvalidDirective                  = errors.New(ErrorInvalidDirective)
+	ErrCannotWireServices                = errors.New(ErrorCannotWireServices)
+	ErrGeneratedCodeIsOutdated           = errors.New(ErrorGeneratedCodeIsOutdated)
+	ErrUnknownProjectTemplate            = errors.New(ErrorUnknownProjectTemplate)
+	ErrProjectFilesAlreadyExist          = errors.New(ErrorProjectFilesAlreadyExist)
+	ErrCannotExtractInterface            = errors.New(ErrorCannotExtractInterface)
+	ErrCannotGenerateConstructor         = errors.New(ErrorCannotGenerateConstructor)
 )
 
 type generatorError struct {
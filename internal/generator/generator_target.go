@@ -6,3 +6,6 @@ import (
 )
 
 type OutputWriterFactory func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error)
+
+// OutputReaderFactory reads previously generated code for the given kind and source file. Returns an error that matches os.ErrNotExist if no code has been generated yet.
+type OutputReaderFactory func(kind string, source *reflection.AstFileSource) ([]byte, error)
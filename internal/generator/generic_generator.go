@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"github.com/matzefriedrich/parsley/pkg/types"
 	"github.com/pkg/errors"
-	"go/format"
 	"io"
 	"reflect"
 	"text/template"
@@ -64,17 +63,21 @@ func (g *genericGenerator) Generate(templateName string, templateModel any, writ
 
 	var generatedCode bytes.Buffer
 
-	t := template.Must(template.New("").Funcs(g.funcMap).Parse(tmpl))
+	// The template name is part of parse and execution errors, for instance, "template: name:12: ...", which helps to locate the offending template line
+	t, err := template.New(templateName).Funcs(g.funcMap).Parse(tmpl)
+	if err != nil {
+		return newGeneratorError(ErrorCannotParseTemplate, types.WithCause(err))
+	}
+
 	err = t.Execute(&generatedCode, templateModel)
 	if err != nil {
 		return newGeneratorError(ErrorCannotExecuteTemplate, types.WithCause(err))
 	}
 
 	code := generatedCode.Bytes()
-	formattedCode, formatErr := format.Source(code)
+	formattedCode, formatErr := FormatSource(code)
 	if formatErr != nil {
-		_, _ = writer.Write(code) // just dump the code to the target writer for inspection
-		return newGeneratorError(ErrorCannotFormatGeneratedCode, types.WithCause(formatErr))
+		return formatErr // reports the offending line of the generated code; nothing is written
 	}
 
 	_, writerErr := writer.Write(formattedCode)
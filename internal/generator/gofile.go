@@ -6,6 +6,7 @@ import (
 	"github.com/matzefriedrich/parsley/internal/reflection"
 	"os"
 	"path"
+	"strings"
 )
 
 // GoFileAccessor Creates a new reflection.AstFileAccessor object that reads a source file from the GOFILE variable.
@@ -42,3 +43,37 @@ func GetGoFilePath() (string, error) {
 
 	return goFilePath, nil
 }
+
+// GoPackageAccessor Creates a new reflection.AstPackageAccessor object that reads all source files of the package containing the file specified by the GOFILE variable.
+func GoPackageAccessor() reflection.AstPackageAccessor {
+
+	goFilePath, err := GetGoFilePath()
+	if err != nil {
+		return func() ([]*reflection.AstFileSource, error) {
+			return nil, newGeneratorError(ErrorFailedToObtainGeneratorSourceFile)
+		}
+	}
+
+	return reflection.AstPackageFromDirectory(path.Dir(goFilePath))
+}
+
+// HasGenerateDirective checks whether the given source code contains a //go:generate directive that runs the generate command of the Parsley CLI for the specified kind, for instance, "//go:generate parsley-cli generate mocks".
+func HasGenerateDirective(code []byte, kind string) bool {
+	for _, line := range strings.Split(string(code), "\n") {
+		command, found := strings.CutPrefix(strings.TrimSpace(line), "//go:generate ")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(command)
+		invokesCli := false
+		for i, field := range fields {
+			if strings.Contains(field, "parsley-cli") {
+				invokesCli = true
+			}
+			if invokesCli && field == "generate" && i+1 < len(fields) && fields[i+1] == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
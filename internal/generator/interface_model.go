@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// InterfaceTemplateModel is the template model of interfaces extracted from the methods of concrete types.
+type InterfaceTemplateModel struct {
+	PackageName string
+	Imports     []ImportModel
+	Interfaces  []ExtractedInterfaceModel
+}
+
+// ExtractedInterfaceModel describes an interface extracted from the exported methods of a type.
+type ExtractedInterfaceModel struct {
+	Name     string
+	TypeName string
+	Methods  []ExtractedMethodModel
+}
+
+// ExtractedMethodModel describes a method of an extracted interface. The signature is copied from the method declaration, so that all parameter and result types are preserved.
+type ExtractedMethodModel struct {
+	Name      string
+	Signature string
+	Doc       []string
+}
+
+// InterfaceOptions configures the extraction of interfaces from concrete types.
+type InterfaceOptions struct {
+	// TypeNames holds the names of the types to extract interfaces from.
+	TypeNames []string
+	// Name is the name of the extracted interface; can only be set if a single type is given. If not set, the name is derived from the type name.
+	Name string
+	// Include restricts the interface to the given methods, if set.
+	Include []string
+	// Exclude removes the given methods from the interface.
+	Exclude []string
+}
+
+// InterfaceNameFrom derives the name of an interface extracted from the given type: unexported type names are exported, for instance, greeter becomes Greeter; the Interface suffix is appended to exported type names.
+func InterfaceNameFrom(typeName string) string {
+	if typeName == "" || unicode.IsUpper([]rune(typeName)[0]) {
+		return typeName + "Interface"
+	}
+	return MakePublic(typeName)
+}
+
+// NewInterfaceTemplateModel collects the exported methods of the given types, declared with value or pointer receivers in any of the given package sources, and creates the template model of the extracted interfaces.
+// Methods promoted from embedded fields are not collected. Returns an error if a type is not declared in the package, is generic, or if a method filter refers to an unknown method.
+func NewInterfaceTemplateModel(sources []*reflection.AstFileSource, options InterfaceOptions) (*InterfaceTemplateModel, error) {
+
+	if len(sources) == 0 {
+		return nil, newInterfaceError("no source files found")
+	}
+	if len(options.TypeNames) == 0 {
+		return nil, newInterfaceError("no type name specified")
+	}
+	if options.Name != "" && len(options.TypeNames) > 1 {
+		return nil, newInterfaceError("the interface name can only be set for a single type")
+	}
+
+	models := make([]*reflection.Model, 0, len(sources))
+	for _, source := range sources {
+		model, err := NewTemplateModelBuilder(source.File).Build()
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, model)
+	}
+
+	result := &InterfaceTemplateModel{
+		PackageName: sources[0].File.Name.Name,
+		Imports:     make([]ImportModel, 0),
+		Interfaces:  make([]ExtractedInterfaceModel, 0, len(options.TypeNames)),
+	}
+
+	imports := make(map[string]string)
+	for _, typeName := range options.TypeNames {
+		extracted, err := extractInterface(sources, models, typeName, options, imports)
+		if err != nil {
+			return nil, err
+		}
+		result.Interfaces = append(result.Interfaces, extracted)
+	}
+
+	for name, importPath := range imports {
+		result.Imports = append(result.Imports, ImportModel{Alias: name, Path: importPath})
+	}
+	slices.SortFunc(result.Imports, func(a, b ImportModel) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return result, nil
+}
+
+func extractInterface(sources []*reflection.AstFileSource, models []*reflection.Model, typeName string, options InterfaceOptions, imports map[string]string) (ExtractedInterfaceModel, error) {
+
+	declared := slices.ContainsFunc(models, func(m *reflection.Model) bool {
+		return slices.ContainsFunc(m.Structs, func(s reflection.Struct) bool {
+			return s.Name == typeName
+		})
+	})
+	if !declared {
+		return ExtractedInterfaceModel{}, newInterfaceError(fmt.Sprintf("struct type %s not found", typeName))
+	}
+
+	name := options.Name
+	if name == "" {
+		name = InterfaceNameFrom(typeName)
+	}
+	extracted := ExtractedInterfaceModel{
+		Name:     name,
+		TypeName: typeName,
+		Methods:  make([]ExtractedMethodModel, 0),
+	}
+
+	found := make([]string, 0)
+	for i, model := range models {
+		usesImports := false
+		for _, method := range model.MethodDecls {
+			if method.ReceiverType != typeName {
+				continue
+			}
+			if method.GenericReceiver {
+				return ExtractedInterfaceModel{}, newInterfaceError(fmt.Sprintf("%s is a generic type; generic types are not supported", typeName))
+			}
+			if !token.IsExported(method.Name) {
+				continue
+			}
+			found = append(found, method.Name)
+			if len(options.Include) > 0 && !slices.Contains(options.Include, method.Name) || slices.Contains(options.Exclude, method.Name) {
+				continue
+			}
+			extracted.Methods = append(extracted.Methods, newExtractedMethodModel(sources[i], method))
+			usesImports = true
+		}
+		if usesImports {
+			if err := mergeImports(imports, sources[i]); err != nil {
+				return ExtractedInterfaceModel{}, err
+			}
+		}
+	}
+
+	for _, methodName := range slices.Concat(options.Include, options.Exclude) {
+		if !slices.Contains(found, methodName) {
+			return ExtractedInterfaceModel{}, newInterfaceError(fmt.Sprintf("%s has no exported method %s", typeName, methodName))
+		}
+	}
+	if len(extracted.Methods) == 0 {
+		return ExtractedInterfaceModel{}, newInterfaceError(fmt.Sprintf("no exported methods of %s match the method filters", typeName))
+	}
+
+	return extracted, nil
+}
+
+func newExtractedMethodModel(source *reflection.AstFileSource, method reflection.MethodDecl) ExtractedMethodModel {
+	doc := make([]string, 0, len(method.Doc))
+	for _, comment := range method.Doc {
+		doc = append(doc, comment.Text)
+	}
+	return ExtractedMethodModel{
+		Name:      method.Name,
+		Signature: source.Text(method.Signature),
+		Doc:       doc,
+	}
+}
+
+// mergeImports adds the imports of the given source file, so that the types referenced by copied method signatures resolve to the same packages. Returns an error if files of the package refer to different packages by the same name.
+func mergeImports(imports map[string]string, source *reflection.AstFileSource) error {
+	for _, spec := range source.File.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := reflection.PackageNameFromImportPath(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		if existing, found := imports[name]; found && existing != importPath {
+			return newInterfaceError(fmt.Sprintf("the name %s refers to both %s and %s", name, existing, importPath))
+		}
+		imports[name] = importPath
+	}
+	return nil
+}
+
+func newInterfaceError(msg string) error {
+	return newGeneratorError(ErrorCannotExtractInterface, types.WithCause(fmt.Errorf("%s", msg)))
+}
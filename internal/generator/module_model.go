@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const registerDirectiveName = "register"
+
+var lifetimeScopeNames = map[string]string{
+	"singleton": "Singleton",
+	"scoped":    "Scoped",
+	"transient": "Transient",
+}
+
+// ModuleTemplateModel is the template model used to generate a ModuleFunc from annotated constructor functions.
+type ModuleTemplateModel struct {
+	PackageName        string
+	FunctionName       string
+	Imports            []string
+	Registrations      []ServiceRegistrationModel
+	NamedRegistrations []NamedServiceRegistrationGroup
+}
+
+// ServiceRegistrationModel describes a single service registration derived from a //parsley:register directive.
+type ServiceRegistrationModel struct {
+	Function    reflection.Function
+	Lifetime    string
+	ServiceType string
+	Name        string
+	Activator   string
+}
+
+// NamedServiceRegistrationGroup groups all named registrations of the same service type, since they must be registered with a single features.RegisterNamed call.
+type NamedServiceRegistrationGroup struct {
+	ServiceType   string
+	Registrations []ServiceRegistrationModel
+}
+
+// ModuleFunctionNameFrom derives the name of a generated module function from the given source file name, for instance, greeter_services.go becomes RegisterGreeterServicesModule.
+func ModuleFunctionNameFrom(sourceFilename string, fallback string) string {
+	fileName := path.Base(sourceFilename)
+	name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	if sourceFilename == "" || name == "" || name == "." {
+		name = fallback
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	buffer := strings.Builder{}
+	for _, word := range words {
+		buffer.WriteString(MakePublic(word))
+	}
+	return fmt.Sprintf("Register%sModule", buffer.String())
+}
+
+// NewModuleTemplateModel builds a ModuleTemplateModel from all functions annotated with a //parsley:register directive.
+// Returns an error if a directive is invalid, or if an annotated function is not a valid activator function.
+func NewModuleTemplateModel(m *reflection.Model, functionName string) (*ModuleTemplateModel, error) {
+
+	model := &ModuleTemplateModel{
+		PackageName:        m.PackageName,
+		FunctionName:       functionName,
+		Registrations:      make([]ServiceRegistrationModel, 0),
+		NamedRegistrations: make([]NamedServiceRegistrationGroup, 0),
+	}
+
+	selectors := make([]string, 0)
+
+	for _, function := range m.Functions {
+		for _, directive := range function.Directives() {
+			if !directive.Is(registerDirectiveName) {
+				continue
+			}
+
+			if err := ValidateActivatorSignature(function, m.ValueTypes); err != nil {
+				return nil, err
+			}
+
+			registration, err := newServiceRegistrationModel(function, directive)
+			if err != nil {
+				return nil, err
+			}
+
+			if registration.ServiceType != "" {
+				selectors = append(selectors, selectorsOf(function, registration.ServiceType)...)
+			}
+
+			if registration.Name == "" {
+				model.Registrations = append(model.Registrations, registration)
+				continue
+			}
+
+			groupIndex := slices.IndexFunc(model.NamedRegistrations, func(g NamedServiceRegistrationGroup) bool {
+				return g.ServiceType == registration.ServiceType
+			})
+			if groupIndex < 0 {
+				model.NamedRegistrations = append(model.NamedRegistrations, NamedServiceRegistrationGroup{ServiceType: registration.ServiceType})
+				groupIndex = len(model.NamedRegistrations) - 1
+			}
+			group := &model.NamedRegistrations[groupIndex]
+			group.Registrations = append(group.Registrations, registration)
+		}
+	}
+
+	imports := []string{"github.com/matzefriedrich/parsley/pkg/types"}
+	if len(model.Registrations) > 0 || len(model.NamedRegistrations) > 0 {
+		imports = append(imports, "github.com/matzefriedrich/parsley/pkg/registration")
+	}
+	if len(model.NamedRegistrations) > 0 {
+		imports = append(imports, "github.com/matzefriedrich/parsley/pkg/features")
+	}
+	for _, importPath := range m.Imports {
+		if slices.Contains(selectors, m.ImportName(importPath)) {
+			imports = append(imports, importPath)
+		}
+	}
+	slices.Sort(imports)
+	model.Imports = slices.Compact(imports)
+
+	return model, nil
+}
+
+func newServiceRegistrationModel(function reflection.Function, directive reflection.Directive) (ServiceRegistrationModel, error) {
+
+	invalid := func(format string, args ...any) error {
+		cause := fmt.Errorf("%s: %s", function.Name, fmt.Sprintf(format, args...))
+		return newGeneratorError(ErrorInvalidDirective, types.WithCause(cause))
+	}
+
+	lifetime := lifetimeScopeNames["transient"]
+	for i, argument := range directive.Arguments {
+		scope, known := lifetimeScopeNames[argument]
+		if !known || i > 0 {
+			return ServiceRegistrationModel{}, invalid("unexpected argument %q; expected one of singleton, scoped, or transient", argument)
+		}
+		lifetime = scope
+	}
+
+	for key := range directive.Options {
+		if key != "as" && key != "name" {
+			return ServiceRegistrationModel{}, invalid("unknown option %q", key)
+		}
+	}
+
+	registration := ServiceRegistrationModel{
+		Function:    function,
+		Lifetime:    lifetime,
+		ServiceType: directive.Option("as"),
+		Name:        directive.Option("name"),
+		Activator:   function.Name,
+	}
+
+	if registration.Name != "" && registration.ServiceType == "" {
+		registration.ServiceType = FormatType(function.Results[0])
+	}
+
+	if registration.ServiceType != "" && registration.ServiceType != FormatType(function.Results[0]) {
+		registration.Activator = activatorWrapper(function, registration.ServiceType)
+	}
+
+	return registration, nil
+}
+
+// activatorWrapper returns a function literal that calls the given function but returns the specified service type instead of the function's return type.
+func activatorWrapper(function reflection.Function, serviceType string) string {
+	resultTypes := serviceType
+	if len(function.Results) == 2 {
+		resultTypes = fmt.Sprintf("(%s, error)", serviceType)
+	}
+	call := fmt.Sprintf("%s(%s)", function.Name, FormattedCallParameters(reflection.Method{Parameters: function.Parameters}))
+	return fmt.Sprintf("func(%s) %s {\n\t\treturn %s\n\t}", FormattedParameters(reflection.Method{Parameters: function.Parameters}), resultTypes, call)
+}
+
+// selectorsOf returns the package selectors referenced by the parameters of the given function and the given service type.
+func selectorsOf(function reflection.Function, serviceType string) []string {
+	selectors := make([]string, 0)
+	for _, p := range function.Parameters {
+		for t := p.Type; t != nil; t = t.Next {
+			if t.SelectorName != "" {
+				selectors = append(selectors, t.SelectorName)
+			}
+		}
+	}
+	if selector, _, found := strings.Cut(strings.TrimLeft(serviceType, "*[]"), "."); found {
+		selectors = append(selectors, selector)
+	}
+	return selectors
+}
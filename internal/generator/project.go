@@ -2,7 +2,7 @@ package generator
 
 import (
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/mod/modfile"
@@ -14,15 +14,17 @@ type goProject struct {
 
 type GoProject interface {
 	AddDependency(packageName string, version string) error
+	DependencyVersion(packageName string) (string, bool, error)
+	SetDependencyVersion(packageName string, version string) error
 }
 
 var _ GoProject = (*goProject)(nil)
 
-// OpenProject opens an existing Go project from the specified folder path and returns a GoProject instance.
+// OpenProject opens an existing Go project from the specified folder path and returns a GoProject instance. If the folder has no go.mod file, the parent folders are searched.
 func OpenProject(projectFolderPath string) (GoProject, error) {
 	modFilePath, found := findModFile(projectFolderPath)
 	if !found {
-		return nil, newProjectError("go.mod file not found", nil)
+		return nil, newProjectError(errorModFileNotFound, nil)
 	}
 	return &goProject{
 		modFilePath: modFilePath,
@@ -32,26 +34,73 @@ func OpenProject(projectFolderPath string) (GoProject, error) {
 // AddDependency Adds the specified package to the current project.
 func (p *goProject) AddDependency(packageName string, version string) error {
 
-	data, err := os.ReadFile(p.modFilePath)
+	modFile, err := p.readModFile()
 	if err != nil {
-		return newProjectError(errorCannotReadModFile, err)
+		return err
 	}
 
-	modFile, err := modfile.Parse(p.modFilePath, data, nil)
+	for _, req := range modFile.Require {
+		if strings.Compare(req.Mod.Path, packageName) == 0 {
+			return nil
+		}
+	}
+
+	if requireErr := modFile.AddRequire(packageName, version); requireErr != nil {
+		return newProjectError(errorFailedToAddRequiredDependency, requireErr)
+	}
+
+	return p.writeModFile(modFile)
+}
+
+// DependencyVersion Returns the required version of the specified package, or false if the project does not require the package.
+func (p *goProject) DependencyVersion(packageName string) (string, bool, error) {
+
+	modFile, err := p.readModFile()
 	if err != nil {
-		return newProjectError(errorCannotParseModFile, err)
+		return "", false, err
 	}
 
 	for _, req := range modFile.Require {
 		if strings.Compare(req.Mod.Path, packageName) == 0 {
-			return nil
+			return req.Mod.Version, true, nil
 		}
 	}
 
+	return "", false, nil
+}
+
+// SetDependencyVersion Sets the required version of the specified package; the requirement is added if the project does not require the package yet.
+func (p *goProject) SetDependencyVersion(packageName string, version string) error {
+
+	modFile, err := p.readModFile()
+	if err != nil {
+		return err
+	}
+
 	if requireErr := modFile.AddRequire(packageName, version); requireErr != nil {
 		return newProjectError(errorFailedToAddRequiredDependency, requireErr)
 	}
 
+	return p.writeModFile(modFile)
+}
+
+func (p *goProject) readModFile() (*modfile.File, error) {
+
+	data, err := os.ReadFile(p.modFilePath)
+	if err != nil {
+		return nil, newProjectError(errorCannotReadModFile, err)
+	}
+
+	modFile, err := modfile.Parse(p.modFilePath, data, nil)
+	if err != nil {
+		return nil, newProjectError(errorCannotParseModFile, err)
+	}
+
+	return modFile, nil
+}
+
+func (p *goProject) writeModFile(modFile *modfile.File) error {
+
 	formattedModData, formatErr := modFile.Format()
 	if formatErr != nil {
 		return newProjectError(errorCannotFormatModFile, formatErr)
@@ -65,9 +114,19 @@ func (p *goProject) AddDependency(packageName string, version string) error {
 }
 
 func findModFile(projectFolderPath string) (string, bool) {
-	modFilePath := path.Join(projectFolderPath, "go.mod")
-	if _, err := os.Stat(modFilePath); os.IsNotExist(err) {
+	folderPath, err := filepath.Abs(projectFolderPath)
+	if err != nil {
 		return "", false
 	}
-	return modFilePath, true
+	for {
+		modFilePath := filepath.Join(folderPath, "go.mod")
+		if _, err := os.Stat(modFilePath); err == nil {
+			return modFilePath, true
+		}
+		parentFolderPath := filepath.Dir(folderPath)
+		if parentFolderPath == folderPath {
+			return "", false
+		}
+		folderPath = parentFolderPath
+	}
 }
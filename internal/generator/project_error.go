@@ -8,6 +8,7 @@ type ProjectError struct {
 }
 
 const (
+	errorModFileNotFound               = "go.mod file not found"
 	errorCannotReadModFile             = "failed to read the go.mod file"
 	errorCannotParseModFile            = "failed to parse go.mod file"
 	errorFailedToAddRequiredDependency = "failed to add the required dependency"
@@ -16,6 +17,7 @@ const (
 )
 
 var (
+	ErrModFileNotFound               = errors.New(errorModFileNotFound)
 	ErrCannotReadModFile             = errors.New(errorCannotReadModFile)
 	ErrCannotParseModFile            = errors.New(errorCannotParseModFile)
 	ErrFailedToAddRequiredDependency = errors.New(errorFailedToAddRequiredDependency)
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+const sourceHashHeaderPrefix = "// Source hash: "
+
+// SourceHash computes a hash over all inputs that determine the generated code, for instance, the source file content and the template.
+func SourceHash(inputs ...[]byte) string {
+	hash := sha256.New()
+	for _, input := range inputs {
+		// Prefix each input with its length, so that different splits of the same bytes yield different hashes
+		_ = binary.Write(hash, binary.LittleEndian, uint64(len(input)))
+		hash.Write(input)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// SourceHashFrom reads the source hash from the header of the given generated code. Returns an empty string if the code has no source hash header.
+func SourceHashFrom(code []byte) string {
+	return headerValue(code, sourceHashHeaderPrefix)
+}
+
+// WithSourceHash adds a source hash header to the given generated code. The header is inserted after the "Code generated" comment, or at the top of the code if there is no such comment.
+func WithSourceHash(code []byte, hash string) []byte {
+	return withHeader(code, sourceHashHeaderPrefix+hash)
+}
@@ -14,6 +14,7 @@ func RegisterTypeModelFunctions(generator GenericCodeGenerator) error {
 		NamedFunc("FormatType", FormatType),
 		NamedFunc("FormattedCallParameters", FormattedCallParameters),
 		NamedFunc("FormattedParameterNames", FormattedParameterNames),
+		NamedFunc("FormattedParameterValues", FormattedParameterValues),
 		NamedFunc("FormattedParameters", FormattedParameters),
 		NamedFunc("FormattedResultNames", FormattedResultNames),
 		NamedFunc("FormattedResultParameters", FormattedResultParameters),
@@ -57,6 +58,15 @@ func FormattedCallParameters(m reflection.Method) string {
 	return strings.Join(formattedParameters, ", ")
 }
 
+// FormattedParameterValues formats the parameter names of the given reflection.Method into a comma-separated string; unlike FormattedCallParameters, variadic parameters are passed as a slice value.
+func FormattedParameterValues(m reflection.Method) string {
+	formattedParameters := make([]string, len(m.Parameters))
+	for i, parameter := range m.Parameters {
+		formattedParameters[i] = parameter.Name
+	}
+	return strings.Join(formattedParameters, ", ")
+}
+
 // FormattedParameterNames formats the parameter names as a comma-separated string of quoted names.
 func FormattedParameterNames(m reflection.Method) string {
 	if m.Parameters == nil {
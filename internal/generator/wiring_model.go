@@ -0,0 +1,637 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const (
+	parsleyFeaturesPackage     = "github.com/matzefriedrich/parsley/pkg/features"
+	parsleyRegistrationPackage = "github.com/matzefriedrich/parsley/pkg/registration"
+	parsleyResolvingPackage    = "github.com/matzefriedrich/parsley/pkg/resolving"
+	parsleyTypesPackage        = "github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// WiringTemplateModel is the template model of a generated container that builds the object graph with direct calls.
+type WiringTemplateModel struct {
+	PackageName   string
+	ContainerName string
+	Imports       []ImportModel
+	Services      []WiredServiceModel
+	ServiceGroups []WiredServiceGroup
+}
+
+// HasLifetime checks whether any of the wired services has the given lifetime scope.
+func (m WiringTemplateModel) HasLifetime(lifetime string) bool {
+	return slices.ContainsFunc(m.Services, func(s WiredServiceModel) bool {
+		return s.Lifetime == lifetime
+	})
+}
+
+// ImportModel represents an import statement of a generated file.
+type ImportModel struct {
+	Alias string
+	Path  string
+}
+
+// String formats the import as an import spec, for instance, `alias "path"`.
+func (i ImportModel) String() string {
+	if i.Alias == "" || i.Alias == reflection.PackageNameFromImportPath(i.Path) {
+		return strconv.Quote(i.Path)
+	}
+	return fmt.Sprintf("%s %s", i.Alias, strconv.Quote(i.Path))
+}
+
+// WiredServiceModel describes a service that is created by the generated container.
+type WiredServiceModel struct {
+	Name           string
+	MethodSuffix   string
+	ServiceType    string
+	Lifetime       string
+	HasErrorReturn bool
+	Dependencies   []WiredDependencyModel
+	Arguments      string
+}
+
+// WiredDependencyModel describes a dependency that must be resolved before a wired service can be activated.
+type WiredDependencyModel struct {
+	Variable     string
+	MethodSuffix string
+}
+
+// WiredServiceGroup groups all wired services that are registered for the same service type.
+type WiredServiceGroup struct {
+	KeyName     string
+	ServiceType string
+	Services    []WiredServiceModel
+}
+
+// WiringOptions configures the static analysis of a package for the wiring generator.
+type WiringOptions struct {
+	ContainerName string
+	Root          string
+	Modules       []string
+}
+
+type wiringFile struct {
+	source  *reflection.AstFileSource
+	imports map[string]string
+}
+
+type wiringCandidate struct {
+	function     reflection.Function
+	file         *wiringFile
+	serviceType  *reflection.ParameterType
+	lifetime     string
+	dependencies []string
+}
+
+type wiringAnalyzer struct {
+	options    WiringOptions
+	files      []*wiringFile
+	functions  map[string]*wiringCandidate
+	candidates []*wiringCandidate
+	valueTypes []string
+}
+
+// NewWiringTemplateModel statically analyzes the given package sources and creates the template model of a container that wires all
+// services registered by ModuleFunc functions, or annotated with a //parsley:register directive, and the root constructor function.
+// Returns an error if a registration cannot be analyzed statically, a dependency is missing or ambiguous, or a circular dependency is detected.
+func NewWiringTemplateModel(sources []*reflection.AstFileSource, options WiringOptions) (*WiringTemplateModel, error) {
+
+	if len(sources) == 0 {
+		return nil, newWiringError("no source files found")
+	}
+
+	analyzer := &wiringAnalyzer{
+		options:    options,
+		files:      make([]*wiringFile, 0, len(sources)),
+		functions:  make(map[string]*wiringCandidate),
+		candidates: make([]*wiringCandidate, 0),
+	}
+
+	if err := analyzer.collectFunctions(sources); err != nil {
+		return nil, err
+	}
+	if err := analyzer.collectAnnotatedRegistrations(); err != nil {
+		return nil, err
+	}
+	if err := analyzer.collectModuleRegistrations(); err != nil {
+		return nil, err
+	}
+	if err := analyzer.addRoot(); err != nil {
+		return nil, err
+	}
+	return analyzer.buildModel(sources[0].File.Name.Name)
+}
+
+func (a *wiringAnalyzer) collectFunctions(sources []*reflection.AstFileSource) error {
+	for _, source := range sources {
+		file := &wiringFile{source: source, imports: importsOf(source.File)}
+		a.files = append(a.files, file)
+		model, err := NewTemplateModelBuilder(source.File).Build()
+		if err != nil {
+			return err
+		}
+		for _, function := range model.Functions {
+			a.functions[function.Name] = &wiringCandidate{function: function, file: file}
+		}
+		a.valueTypes = append(a.valueTypes, model.ValueTypes...)
+	}
+	return nil
+}
+
+func (a *wiringAnalyzer) collectAnnotatedRegistrations() error {
+	for _, file := range a.files {
+		model, _ := NewTemplateModelBuilder(file.source.File).Build()
+		for _, function := range model.Functions {
+			for _, directive := range function.Directives() {
+				if !directive.Is(registerDirectiveName) {
+					continue
+				}
+				registration, err := newServiceRegistrationModel(function, directive)
+				if err != nil {
+					return err
+				}
+				if registration.Name != "" {
+					return newWiringError(fmt.Sprintf("%s: named services are not supported", function.Name))
+				}
+				if err := a.register(function.Name, registration.Lifetime, registration.ServiceType); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *wiringAnalyzer) collectModuleRegistrations() error {
+
+	modules := make(map[string]*ast.FuncDecl)
+	moduleFiles := make(map[string]*wiringFile)
+	generatedModules := make(map[string]struct{})
+	for _, file := range a.files {
+		generated := IsGeneratedByParsley(file.source.Content)
+		for _, decl := range file.source.File.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || !isModuleFunc(funcDecl, file.imports) {
+				continue
+			}
+			if generated {
+				// Modules generated by the module generator register the annotated functions, which are collected from their directives
+				generatedModules[funcDecl.Name.Name] = struct{}{}
+				continue
+			}
+			modules[funcDecl.Name.Name] = funcDecl
+			moduleFiles[funcDecl.Name.Name] = file
+		}
+	}
+
+	pending := slices.Clone(a.options.Modules)
+	if len(pending) == 0 {
+		for name := range modules {
+			pending = append(pending, name)
+		}
+		slices.Sort(pending)
+	}
+
+	visited := make(map[string]struct{})
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, seen := visited[name]; seen {
+			continue
+		}
+		visited[name] = struct{}{}
+		if _, generated := generatedModules[name]; generated {
+			continue
+		}
+		module, found := modules[name]
+		if !found {
+			return newWiringError(fmt.Sprintf("module function %s not found", name))
+		}
+		referencedModules, err := a.analyzeModule(module, moduleFiles[name])
+		if err != nil {
+			return err
+		}
+		pending = append(pending, referencedModules...)
+	}
+
+	return nil
+}
+
+// analyzeModule collects the registrations of the given ModuleFunc and returns the names of modules it registers.
+func (a *wiringAnalyzer) analyzeModule(module *ast.FuncDecl, file *wiringFile) ([]string, error) {
+
+	registryName := module.Type.Params.List[0].Names[0].Name
+	referencedModules := make([]string, 0)
+
+	var analyzeErr error
+	ast.Inspect(module.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || analyzeErr != nil {
+			return analyzeErr == nil
+		}
+		fun := call.Fun
+		if index, isIndex := fun.(*ast.IndexExpr); isIndex {
+			fun = index.X
+		}
+		selector, ok := fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		receiver, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		methodName := selector.Sel.Name
+		location := fmt.Sprintf("%s: %s.%s", module.Name.Name, receiver.Name, methodName)
+
+		switch {
+		case file.imports[receiver.Name] == parsleyRegistrationPackage:
+			lifetime, supported := strings.CutPrefix(methodName, "Register")
+			if _, known := lifetimeScopeNames[strings.ToLower(lifetime)]; !supported || !known {
+				analyzeErr = newWiringError(fmt.Sprintf("%s: registration is not supported", location))
+				return false
+			}
+			for _, arg := range call.Args[1:] {
+				ident, isIdent := arg.(*ast.Ident)
+				if !isIdent {
+					analyzeErr = newWiringError(fmt.Sprintf("%s: only top-level functions of the package are supported as activator functions", location))
+					return false
+				}
+				if analyzeErr = a.register(ident.Name, lifetime, ""); analyzeErr != nil {
+					return false
+				}
+			}
+		case file.imports[receiver.Name] == parsleyFeaturesPackage:
+			analyzeErr = newWiringError(fmt.Sprintf("%s: registration is not supported", location))
+			return false
+		case receiver.Name == registryName && methodName == "Register" && len(call.Args) == 2:
+			ident, isIdent := call.Args[0].(*ast.Ident)
+			scope, isScope := call.Args[1].(*ast.SelectorExpr)
+			if !isIdent || !isScope {
+				analyzeErr = newWiringError(fmt.Sprintf("%s: only top-level functions and constant lifetime scopes are supported", location))
+				return false
+			}
+			lifetime := strings.TrimPrefix(scope.Sel.Name, "Lifetime")
+			analyzeErr = a.register(ident.Name, lifetime, "")
+		case receiver.Name == registryName && (methodName == "RegisterModule" || methodName == "RegisterModuleIf"):
+			args := call.Args
+			if methodName == "RegisterModuleIf" && len(args) > 0 {
+				args = args[1:]
+			}
+			for _, arg := range args {
+				ident, isIdent := arg.(*ast.Ident)
+				if !isIdent {
+					analyzeErr = newWiringError(fmt.Sprintf("%s: only top-level module functions are supported", location))
+					return false
+				}
+				referencedModules = append(referencedModules, ident.Name)
+			}
+		case receiver.Name == registryName:
+			analyzeErr = newWiringError(fmt.Sprintf("%s: registration is not supported", location))
+			return false
+		}
+		return true
+	})
+
+	return referencedModules, analyzeErr
+}
+
+func (a *wiringAnalyzer) addRoot() error {
+	root := a.options.Root
+	if root == "" {
+		return nil
+	}
+	candidate, found := a.functions[root]
+	if !found {
+		return newWiringError(fmt.Sprintf("root constructor function %s not found", root))
+	}
+	if slices.Contains(a.candidates, candidate) {
+		return nil
+	}
+	return a.register(root, lifetimeScopeNames["singleton"], "")
+}
+
+func (a *wiringAnalyzer) register(functionName string, lifetime string, serviceType string) error {
+
+	candidate, found := a.functions[functionName]
+	if !found {
+		return newWiringError(fmt.Sprintf("activator function %s not found in package", functionName))
+	}
+
+	if err := ValidateActivatorSignature(candidate.function, a.valueTypes); err != nil {
+		return err
+	}
+
+	if _, known := lifetimeScopeNames[strings.ToLower(lifetime)]; !known {
+		return newWiringError(fmt.Sprintf("%s: unknown lifetime scope %s", functionName, lifetime))
+	}
+
+	t := candidate.function.Results[0].Type
+	if serviceType != "" {
+		parsed, err := reflection.ParseTypeExpression(serviceType)
+		if err != nil {
+			return newWiringError(fmt.Sprintf("%s: invalid service type %s", functionName, serviceType))
+		}
+		t = parsed
+	}
+
+	if slices.Contains(a.candidates, candidate) {
+		if candidate.lifetime != lifetime {
+			return newWiringError(fmt.Sprintf("%s: registered with different lifetime scopes", functionName))
+		}
+		return nil
+	}
+
+	candidate.lifetime = lifetime
+	candidate.serviceType = t
+	a.candidates = append(a.candidates, candidate)
+	return nil
+}
+
+func (a *wiringAnalyzer) buildModel(packageName string) (*WiringTemplateModel, error) {
+
+	containerName := a.options.ContainerName
+	if containerName == "" {
+		containerName = "WiredContainer"
+	}
+
+	aliases := newImportAliases("context", "sync", "types", "resolving")
+	aliases.add(parsleyTypesPackage)
+
+	groups := make(map[string][]*wiringCandidate)
+	groupKeys := make([]string, 0)
+	for _, candidate := range a.candidates {
+		key := canonicalType(candidate.serviceType, candidate.file.imports)
+		if _, found := groups[key]; !found {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], candidate)
+	}
+
+	resolverKey := canonicalType(&reflection.ParameterType{SelectorName: "types", Name: "Resolver"}, map[string]string{"types": parsleyTypesPackage})
+
+	for _, candidate := range a.candidates {
+		candidate.dependencies = make([]string, 0)
+		for i, parameter := range candidate.function.Parameters {
+			if i == 0 && IsContextParameter(parameter) {
+				continue
+			}
+			key := canonicalType(parameter.Type, candidate.file.imports)
+			if key == resolverKey {
+				continue
+			}
+			providers := groups[key]
+			switch len(providers) {
+			case 0:
+				return nil, newWiringError(fmt.Sprintf("%s: missing service registration for %s", candidate.function.Name, FormatType(parameter)))
+			case 1:
+				candidate.dependencies = append(candidate.dependencies, providers[0].function.Name)
+			default:
+				return nil, newWiringError(fmt.Sprintf("%s: ambiguous service registrations for %s", candidate.function.Name, FormatType(parameter)))
+			}
+		}
+	}
+
+	if err := a.detectCircularDependencies(); err != nil {
+		return nil, err
+	}
+
+	model := &WiringTemplateModel{
+		PackageName:   packageName,
+		ContainerName: containerName,
+		Services:      make([]WiredServiceModel, 0, len(a.candidates)),
+		ServiceGroups: make([]WiredServiceGroup, 0, len(groupKeys)),
+	}
+
+	services := make(map[string]WiredServiceModel)
+	for _, candidate := range a.candidates {
+		service := WiredServiceModel{
+			Name:           candidate.function.Name,
+			MethodSuffix:   MakePublic(candidate.function.Name),
+			ServiceType:    aliases.format(candidate.serviceType, candidate.file.imports),
+			Lifetime:       candidate.lifetime,
+			HasErrorReturn: len(candidate.function.Results) == 2,
+			Dependencies:   make([]WiredDependencyModel, 0),
+		}
+		arguments := make([]string, 0, len(candidate.function.Parameters))
+		dependencyIndex := 0
+		for i, parameter := range candidate.function.Parameters {
+			switch {
+			case i == 0 && IsContextParameter(parameter):
+				arguments = append(arguments, "ctx")
+			case canonicalType(parameter.Type, candidate.file.imports) == resolverKey:
+				arguments = append(arguments, "c")
+			default:
+				variable := fmt.Sprintf("p%d", i)
+				service.Dependencies = append(service.Dependencies, WiredDependencyModel{
+					Variable:     variable,
+					MethodSuffix: MakePublic(candidate.dependencies[dependencyIndex]),
+				})
+				dependencyIndex++
+				if parameter.IsEllipsis() {
+					variable += "..."
+				}
+				arguments = append(arguments, variable)
+			}
+		}
+		service.Arguments = strings.Join(arguments, ", ")
+		services[service.Name] = service
+		model.Services = append(model.Services, service)
+	}
+
+	for i, key := range groupKeys {
+		candidates := groups[key]
+		group := WiredServiceGroup{
+			KeyName:     fmt.Sprintf("%sServiceKey%d", MakePrivate(containerName), i),
+			ServiceType: services[candidates[0].function.Name].ServiceType,
+		}
+		for _, candidate := range candidates {
+			group.Services = append(group.Services, services[candidate.function.Name])
+		}
+		model.ServiceGroups = append(model.ServiceGroups, group)
+	}
+
+	imports := []ImportModel{{Path: "context"}}
+	if model.HasLifetime(lifetimeScopeNames["singleton"]) {
+		imports = append(imports, ImportModel{Path: "sync"})
+	}
+	if model.HasLifetime(lifetimeScopeNames["scoped"]) {
+		imports = append(imports, ImportModel{Path: parsleyResolvingPackage})
+	}
+	imports = append(imports, aliases.imports()...)
+	slices.SortFunc(imports, func(x, y ImportModel) int {
+		return strings.Compare(x.Path, y.Path)
+	})
+	model.Imports = slices.Compact(imports)
+
+	return model, nil
+}
+
+func (a *wiringAnalyzer) detectCircularDependencies() error {
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int)
+
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		switch state[name] {
+		case visiting:
+			return newWiringError(fmt.Sprintf("%s: %s", types.ErrorCircularDependencyDetected, strings.Join(append(chain, name), " -> ")))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dependency := range a.functions[name].dependencies {
+			if err := visit(dependency, append(chain, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, candidate := range a.candidates {
+		if err := visit(candidate.function.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newWiringError(msg string) error {
+	return newGeneratorError(ErrorCannotWireServices, types.WithCause(fmt.Errorf("%s", msg)))
+}
+
+// isModuleFunc checks whether the given function declaration matches the types.ModuleFunc signature.
+func isModuleFunc(funcDecl *ast.FuncDecl, imports map[string]string) bool {
+	if funcDecl.Recv != nil || funcDecl.Body == nil {
+		return false
+	}
+	params := funcDecl.Type.Params.List
+	results := funcDecl.Type.Results
+	if len(params) != 1 || len(params[0].Names) != 1 || results == nil || len(results.List) != 1 {
+		return false
+	}
+	selector, ok := params[0].Type.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	receiver, ok := selector.X.(*ast.Ident)
+	return ok && imports[receiver.Name] == parsleyTypesPackage && selector.Sel.Name == "ServiceRegistry"
+}
+
+// importsOf maps the package selectors used in the given file to the imported package paths.
+func importsOf(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		selector := reflection.PackageNameFromImportPath(importPath)
+		if spec.Name != nil {
+			selector = spec.Name.Name
+		}
+		imports[selector] = importPath
+	}
+	return imports
+}
+
+// canonicalType formats the given type with fully qualified package paths, so that types referenced from different files can be compared.
+func canonicalType(t *reflection.ParameterType, imports map[string]string) string {
+	qualified := requalify(t, func(selector string) string {
+		if importPath, found := imports[selector]; found {
+			return strconv.Quote(importPath)
+		}
+		return selector
+	})
+	return FormatType(reflection.Parameter{Type: qualified})
+}
+
+func requalify(t *reflection.ParameterType, selectorFunc func(selector string) string) *reflection.ParameterType {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	if clone.SelectorName != "" {
+		clone.SelectorName = selectorFunc(clone.SelectorName)
+	}
+	clone.Next = requalify(t.Next, selectorFunc)
+	return &clone
+}
+
+type importAliases struct {
+	reserved []string
+	aliases  map[string]string
+}
+
+func newImportAliases(reserved ...string) *importAliases {
+	return &importAliases{
+		reserved: reserved,
+		aliases:  make(map[string]string),
+	}
+}
+
+// add registers the given package path and returns a unique alias for it.
+func (i *importAliases) add(importPath string) string {
+	if alias, found := i.aliases[importPath]; found {
+		return alias
+	}
+	base := path.Base(importPath)
+	alias := base
+	inUse := func(alias string) bool {
+		for p, a := range i.aliases {
+			if a == alias && p != importPath {
+				return true
+			}
+		}
+		return slices.Contains(i.reserved, alias) && !isReservedPath(alias, importPath)
+	}
+	for n := 1; inUse(alias); n++ {
+		alias = fmt.Sprintf("%s%d", base, n)
+	}
+	i.aliases[importPath] = alias
+	return alias
+}
+
+func isReservedPath(alias string, importPath string) bool {
+	switch alias {
+	case "types":
+		return importPath == parsleyTypesPackage
+	case "resolving":
+		return importPath == parsleyResolvingPackage
+	default:
+		return alias == importPath
+	}
+}
+
+// format formats the given type using the aliases of the generated file.
+func (i *importAliases) format(t *reflection.ParameterType, imports map[string]string) string {
+	qualified := requalify(t, func(selector string) string {
+		if importPath, found := imports[selector]; found {
+			return i.add(importPath)
+		}
+		return selector
+	})
+	return FormatType(reflection.Parameter{Type: qualified})
+}
+
+func (i *importAliases) imports() []ImportModel {
+	imports := make([]ImportModel, 0, len(i.aliases))
+	for importPath, alias := range i.aliases {
+		imports = append(imports, ImportModel{Alias: alias, Path: importPath})
+	}
+	return imports
+}
@@ -0,0 +1,70 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Compatibility describes whether code written for, or generated by, one Parsley version works with another.
+type Compatibility struct {
+	Older string
+	Newer string
+	// MajorVersionChanged indicates that the versions have different major versions.
+	MajorVersionChanged bool
+	// BreakingChanges holds the rules for breaking changes introduced after the older version, up to and including the newer version.
+	BreakingChanges []Rule
+}
+
+// Compatible reports whether the versions are compatible.
+func (c Compatibility) Compatible() bool {
+	return !c.MajorVersionChanged && len(c.BreakingChanges) == 0
+}
+
+// String describes why the versions are incompatible, or returns an empty string if they are compatible.
+func (c Compatibility) String() string {
+	if c.MajorVersionChanged {
+		return fmt.Sprintf("the major versions of %s and %s differ", c.Older, c.Newer)
+	}
+	if len(c.BreakingChanges) == 0 {
+		return ""
+	}
+	changes := make([]string, 0, len(c.BreakingChanges))
+	for _, rule := range c.BreakingChanges {
+		changes = append(changes, fmt.Sprintf("%s (%s)", rule.Version, rule.Name))
+	}
+	return fmt.Sprintf("breaking changes between %s and %s: %s", c.Older, c.Newer, strings.Join(changes, ", "))
+}
+
+// CheckCompatibility checks whether the given versions are compatible; the order of the versions does not matter. Versions are incompatible if their major versions differ, or if any rule of the registry describes a breaking change between them.
+func (r *RuleRegistry) CheckCompatibility(a string, b string) (Compatibility, error) {
+
+	a, aErr := CanonicalVersion(a)
+	if aErr != nil {
+		return Compatibility{}, aErr
+	}
+
+	b, bErr := CanonicalVersion(b)
+	if bErr != nil {
+		return Compatibility{}, bErr
+	}
+
+	if semver.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+
+	compatibility := Compatibility{
+		Older:               a,
+		Newer:               b,
+		MajorVersionChanged: semver.Major(a) != semver.Major(b),
+	}
+
+	rules, err := r.RulesBetween(a, b)
+	if err != nil {
+		return Compatibility{}, err
+	}
+	compatibility.BreakingChanges = rules
+
+	return compatibility, nil
+}
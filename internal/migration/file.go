@@ -0,0 +1,172 @@
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// File represents a Go source file to migrate. Rules record text edits instead of modifying the syntax tree, so that the formatting and comments of unchanged code are preserved.
+type File struct {
+	Filename string
+	Syntax   *ast.File
+	Package  *Package
+	content  []byte
+	fileSet  *token.FileSet
+	edits    []edit
+	imports  []string
+}
+
+// Package represents the source files of a directory. Rules use it to look up declarations in sibling files.
+type Package struct {
+	Dir   string
+	Files []*File
+}
+
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// Replace replaces the source code between the given positions with the specified text.
+func (f *File) Replace(pos token.Pos, end token.Pos, text string) {
+	f.edits = append(f.edits, edit{
+		start: f.offset(pos),
+		end:   f.offset(end),
+		text:  text,
+	})
+}
+
+// Insert inserts the given text at the specified position.
+func (f *File) Insert(pos token.Pos, text string) {
+	f.Replace(pos, pos, text)
+}
+
+// Delete removes the source code between the given positions.
+func (f *File) Delete(pos token.Pos, end token.Pos) {
+	f.Replace(pos, end, "")
+}
+
+// AddImport adds the given import path to the file unless it is already imported.
+func (f *File) AddImport(importPath string) {
+	if _, found := f.ImportName(importPath); found || slices.Contains(f.imports, importPath) {
+		return
+	}
+	f.imports = append(f.imports, importPath)
+}
+
+// ImportName returns the name under which the file refers to the specified import path. Returns false if the package is not imported, or if it is imported for side effects or with a dot.
+func (f *File) ImportName(importPath string) (string, bool) {
+	for _, spec := range f.Syntax.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != importPath {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return "", false
+			}
+			return spec.Name.Name, true
+		}
+		return reflection.PackageNameFromImportPath(path), true
+	}
+	return "", false
+}
+
+// Text returns the source code of the given node.
+func (f *File) Text(node ast.Node) string {
+	return string(f.content[f.offset(node.Pos()):f.offset(node.End())])
+}
+
+// Position returns the file position of the given node, for instance, to report code that cannot be migrated.
+func (f *File) Position(node ast.Node) token.Position {
+	return f.fileSet.Position(node.Pos())
+}
+
+func (f *File) offset(pos token.Pos) int {
+	return f.fileSet.Position(pos).Offset
+}
+
+func (f *File) changed() bool {
+	return len(f.edits) > 0 || len(f.imports) > 0
+}
+
+// apply returns the source code of the file with all recorded edits applied, formatted with go/format.
+func (f *File) apply() ([]byte, error) {
+
+	edits := slices.Clone(f.edits)
+	edits = append(edits, f.importEdits()...)
+	slices.SortStableFunc(edits, func(a, b edit) int {
+		return a.start - b.start
+	})
+
+	var code bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		if e.start < last {
+			return nil, newMigrationError(ErrorConflictingEdits, types.WithCause(fmt.Errorf("%s: overlapping edits at offset %d", f.Filename, e.start)))
+		}
+		code.Write(f.content[last:e.start])
+		code.WriteString(e.text)
+		last = e.end
+	}
+	code.Write(f.content[last:])
+
+	formatted, err := format.Source(code.Bytes())
+	if err != nil {
+		return nil, newMigrationError(ErrorCannotFormatMigratedCode, types.WithCause(fmt.Errorf("%s: %w", f.Filename, err)))
+	}
+	return formatted, nil
+}
+
+// importEdits creates the edits that add the required imports. The imports are added to the first group of the import block if that group holds standard library packages, or as a separate group otherwise; go/format sorts them.
+func (f *File) importEdits() []edit {
+
+	if len(f.imports) == 0 {
+		return nil
+	}
+
+	var specs bytes.Buffer
+	for _, importPath := range f.imports {
+		specs.WriteString("\t" + strconv.Quote(importPath) + "\n")
+	}
+
+	for _, decl := range f.Syntax.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT || len(genDecl.Specs) == 0 {
+			continue
+		}
+		separator := "\n"
+		if first, isImport := genDecl.Specs[0].(*ast.ImportSpec); isImport && isStandardLibraryImport(first) {
+			separator = ""
+		}
+		if genDecl.Lparen.IsValid() {
+			start := f.offset(genDecl.Lparen) + 1
+			return []edit{{start: start, end: start, text: "\n" + strings.TrimSuffix(specs.String(), "\n") + separator}}
+		}
+		start, end := f.offset(genDecl.Pos()), f.offset(genDecl.End())
+		existing := string(f.content[f.offset(genDecl.Specs[0].Pos()):end])
+		return []edit{{start: start, end: end, text: "import (\n" + specs.String() + separator + "\t" + existing + "\n)"}}
+	}
+
+	end := f.offset(f.Syntax.Name.End())
+	return []edit{{start: end, end: end, text: "\n\nimport (\n" + specs.String() + ")"}}
+}
+
+func isStandardLibraryImport(spec *ast.ImportSpec) bool {
+	importPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return false
+	}
+	firstElement, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(firstElement, ".")
+}
@@ -0,0 +1,42 @@
+package migration
+
+import (
+	"errors"
+
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const (
+	ErrorInvalidVersion           = "invalid version"
+	ErrorInvalidVersionRange      = "invalid version range"
+	ErrorCannotLoadSourceFiles    = "cannot load source files"
+	ErrorConflictingEdits         = "conflicting edits"
+	ErrorCannotFormatMigratedCode = "cannot format migrated code"
+)
+
+var (
+	ErrInvalidVersion           = errors.New(ErrorInvalidVersion)
+	ErrInvalidVersionRange      = errors.New(ErrorInvalidVersionRange)
+	ErrCannotLoadSourceFiles    = errors.New(ErrorCannotLoadSourceFiles)
+	ErrConflictingEdits         = errors.New(ErrorConflictingEdits)
+	ErrCannotFormatMigratedCode = errors.New(ErrorCannotFormatMigratedCode)
+)
+
+type migrationError struct {
+	types.ParsleyError
+}
+
+var _ error = &migrationError{}
+
+func newMigrationError(msg string, initializers ...types.ParsleyErrorFunc) error {
+	err := &migrationError{
+		ParsleyError: types.ParsleyError{
+			Msg: msg,
+		},
+	}
+	for _, initializer := range initializers {
+		initializer(&err.ParsleyError)
+		initializer(err)
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package migration
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// FileChange describes a source file changed by a migration.
+type FileChange struct {
+	Filename string
+	Original []byte
+	Migrated []byte
+	// Rules holds the names of the rules that changed the file.
+	Rules []string
+}
+
+// Migrate applies the given rules to all Go source files below the root directory, including test files, and returns the changed files. Files are not written.
+// The vendor and testdata directories, as well as directories starting with a dot or an underscore, are skipped.
+func Migrate(root string, rules []Rule) ([]FileChange, error) {
+
+	packages, err := LoadPackages(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return MigratePackages(packages, rules)
+}
+
+// MigratePackages applies the given rules to the files of the specified packages and returns the changed files.
+func MigratePackages(packages []*Package, rules []Rule) ([]FileChange, error) {
+
+	appliedRules := make(map[*File][]string)
+	for _, rule := range rules {
+		for _, p := range packages {
+			for _, f := range p.Files {
+				n := len(f.edits) + len(f.imports)
+				rule.Rewrite(f)
+				if len(f.edits)+len(f.imports) > n {
+					appliedRules[f] = append(appliedRules[f], rule.Name)
+				}
+			}
+		}
+	}
+
+	changes := make([]FileChange, 0)
+	for _, p := range packages {
+		for _, f := range p.Files {
+			if !f.changed() {
+				continue
+			}
+			migrated, err := f.apply()
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, FileChange{
+				Filename: f.Filename,
+				Original: f.content,
+				Migrated: migrated,
+				Rules:    appliedRules[f],
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// LoadPackages parses the Go source files below the root directory and groups them by directory.
+func LoadPackages(root string) ([]*Package, error) {
+
+	packagesByDir := make(map[string]*Package)
+
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if filePath != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(name) != ".go" {
+			return nil
+		}
+		content, readErr := os.ReadFile(filePath)
+		if readErr != nil {
+			return readErr
+		}
+		dir := filepath.Dir(filePath)
+		p, found := packagesByDir[dir]
+		if !found {
+			p = &Package{Dir: dir}
+			packagesByDir[dir] = p
+		}
+		_, parseErr := ParseFile(p, filePath, content)
+		return parseErr
+	})
+	if err != nil {
+		return nil, newMigrationError(ErrorCannotLoadSourceFiles, types.WithCause(err))
+	}
+
+	packages := make([]*Package, 0, len(packagesByDir))
+	for _, p := range packagesByDir {
+		packages = append(packages, p)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Dir < packages[j].Dir
+	})
+
+	return packages, nil
+}
+
+// ParseFile parses the given source code and adds the file to the specified package.
+func ParseFile(p *Package, filename string, content []byte) (*File, error) {
+	fileSet := token.NewFileSet()
+	syntax, err := parser.ParseFile(fileSet, filename, content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	f := &File{
+		Filename: filename,
+		Syntax:   syntax,
+		Package:  p,
+		content:  content,
+		fileSet:  fileSet,
+	}
+	p.Files = append(p.Files, f)
+	return f, nil
+}
@@ -0,0 +1,96 @@
+package migration
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"golang.org/x/mod/semver"
+)
+
+// RewriteFunc records the edits required to migrate the given file. Rewrite functions must not modify the syntax tree; use the edit methods of File instead.
+type RewriteFunc func(f *File)
+
+// Rule describes how code affected by a breaking change of the Parsley API gets rewritten.
+type Rule struct {
+	// Version is the Parsley version that introduced the breaking change, for instance, v1.5.0.
+	Version string
+	// Name identifies the rule in the output of the migrate command.
+	Name        string
+	Description string
+	Rewrite     RewriteFunc
+}
+
+// RuleRegistry holds the migration rules known by the CLI.
+type RuleRegistry struct {
+	rules []Rule
+}
+
+// NewRuleRegistry creates a new RuleRegistry containing the given rules.
+func NewRuleRegistry(rules ...Rule) *RuleRegistry {
+	registry := &RuleRegistry{
+		rules: make([]Rule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		registry.Register(rule)
+	}
+	return registry
+}
+
+// DefaultRuleRegistry creates a RuleRegistry containing the rules for all breaking changes of the Parsley API.
+func DefaultRuleRegistry() *RuleRegistry {
+	return NewRuleRegistry(
+		LazyValueContextRule(),
+		RegisterListWithoutContextRule(),
+		RegisterNamedWithoutContextRule(),
+		ServiceTypeFromErrorRule())
+}
+
+// Register adds the given rule to the registry.
+func (r *RuleRegistry) Register(rule Rule) {
+	r.rules = append(r.rules, rule)
+}
+
+// RulesBetween returns the rules for breaking changes introduced after the from version, up to and including the to version, ordered by version.
+// Versions can be abbreviated, for instance, v1.4 means v1.4.0.
+func (r *RuleRegistry) RulesBetween(from string, to string) ([]Rule, error) {
+
+	from, fromErr := CanonicalVersion(from)
+	if fromErr != nil {
+		return nil, fromErr
+	}
+
+	to, toErr := CanonicalVersion(to)
+	if toErr != nil {
+		return nil, toErr
+	}
+
+	if semver.Compare(from, to) > 0 {
+		return nil, newMigrationError(ErrorInvalidVersionRange, types.WithCause(fmt.Errorf("%s is newer than %s", from, to)))
+	}
+
+	rules := make([]Rule, 0)
+	for _, rule := range r.rules {
+		if semver.Compare(rule.Version, from) > 0 && semver.Compare(rule.Version, to) <= 0 {
+			rules = append(rules, rule)
+		}
+	}
+
+	slices.SortStableFunc(rules, func(a, b Rule) int {
+		return semver.Compare(a.Version, b.Version)
+	})
+
+	return rules, nil
+}
+
+// CanonicalVersion returns the canonical form of the given semantic version, for instance, v1.6.0 for 1.6. Pre-release suffixes are kept.
+func CanonicalVersion(version string) (string, error) {
+	if len(version) > 0 && version[0] != 'v' {
+		version = "v" + version
+	}
+	canonical := semver.Canonical(version)
+	if canonical == "" {
+		return "", newMigrationError(ErrorInvalidVersion, types.WithCause(fmt.Errorf("%q is not a semantic version", version)))
+	}
+	return canonical, nil
+}
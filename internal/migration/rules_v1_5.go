@@ -0,0 +1,101 @@
+package migration
+
+import (
+	"go/ast"
+)
+
+// LazyValueContextRule creates a Rule that passes a context.Context to the Value method of Lazy[T] services (changed in v1.5.0).
+// The rule rewrites calls on parameters, variables, and struct fields declared as features.Lazy[T]; the context parameter of the enclosing function is used if available, otherwise context.TODO().
+func LazyValueContextRule() Rule {
+	return Rule{
+		Version:     "v1.5.0",
+		Name:        "lazy-value-context",
+		Description: "Lazy[T].Value() requires a context.Context parameter",
+		Rewrite: func(f *File) {
+			lazyFields := lazyFieldNames(f.Package)
+			inspect(f.Syntax, func(n ast.Node, stack []ast.Node) {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) > 0 {
+					return
+				}
+				selector, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || selector.Sel.Name != "Value" {
+					return
+				}
+				if isLazyValue(f, selector.X, lazyFields) {
+					f.Insert(call.Rparen, contextExprFor(f, stack))
+				}
+			})
+		},
+	}
+}
+
+// RegisterListWithoutContextRule creates a Rule that removes the context.Context argument from calls of RegisterList (changed in v1.5.0).
+func RegisterListWithoutContextRule() Rule {
+	return Rule{
+		Version:     "v1.5.0",
+		Name:        "register-list-without-context",
+		Description: "RegisterList[T] no longer accepts a context.Context parameter",
+		Rewrite: func(f *File) {
+			inspect(f.Syntax, func(n ast.Node, _ []ast.Node) {
+				call, ok := n.(*ast.CallExpr)
+				if ok && len(call.Args) == 2 && isPackageCall(f, call, featuresPackage, "RegisterList") {
+					f.Delete(call.Args[0].Pos(), call.Args[1].Pos())
+				}
+			})
+		},
+	}
+}
+
+// RegisterNamedWithoutContextRule creates a Rule that removes the context.Context argument from calls of RegisterNamed (changed in v1.5.2).
+func RegisterNamedWithoutContextRule() Rule {
+	return Rule{
+		Version:     "v1.5.2",
+		Name:        "register-named-without-context",
+		Description: "RegisterNamed[T] no longer accepts a context.Context parameter",
+		Rewrite: func(f *File) {
+			inspect(f.Syntax, func(n ast.Node, _ []ast.Node) {
+				call, ok := n.(*ast.CallExpr)
+				if ok && len(call.Args) >= 2 && isPackageCall(f, call, featuresPackage, "RegisterNamed") && isContextExpr(f, call.Args[0]) {
+					f.Delete(call.Args[0].Pos(), call.Args[1].Pos())
+				}
+			})
+		},
+	}
+}
+
+// isLazyValue determines whether the given expression refers to a features.Lazy[T] value: a parameter or variable declared as Lazy[T], or a field with the name of a Lazy[T] struct field of the package.
+func isLazyValue(f *File, expr ast.Expr, lazyFields map[string]struct{}) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		t, ok := declaredType(e)
+		return ok && isPackageType(f, t, featuresPackage, "Lazy")
+	case *ast.SelectorExpr:
+		_, found := lazyFields[e.Sel.Name]
+		return found
+	}
+	return false
+}
+
+// lazyFieldNames collects the names of all struct fields of the package declared as features.Lazy[T].
+func lazyFieldNames(p *Package) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, f := range p.Files {
+		ast.Inspect(f.Syntax, func(n ast.Node) bool {
+			structType, ok := n.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range structType.Fields.List {
+				if !isPackageType(f, field.Type, featuresPackage, "Lazy") {
+					continue
+				}
+				for _, name := range field.Names {
+					names[name.Name] = struct{}{}
+				}
+			}
+			return true
+		})
+	}
+	return names
+}
@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"go/ast"
+)
+
+// ServiceTypeFromErrorRule creates a Rule that replaces calls of ServiceTypeFrom with MustServiceTypeFrom, since ServiceTypeFrom returns an error instead of panicking (changed in v1.7.0).
+// The rewritten code keeps the previous behavior; callers that want to handle unsupported types should switch to ServiceTypeFrom and check the error.
+func ServiceTypeFromErrorRule() Rule {
+	return Rule{
+		Version:     "v1.7.0",
+		Name:        "service-type-from-error",
+		Description: "ServiceTypeFrom returns an error for unsupported types instead of panicking",
+		Rewrite: func(f *File) {
+			inspect(f.Syntax, func(n ast.Node, _ []ast.Node) {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || !isPackageCall(f, call, typesPackage, "ServiceTypeFrom") {
+					return
+				}
+				if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+					f.Replace(selector.Sel.Pos(), selector.Sel.End(), "MustServiceTypeFrom")
+				}
+			})
+		},
+	}
+}
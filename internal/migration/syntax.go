@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"go/ast"
+)
+
+const (
+	contextPackage  = "context"
+	featuresPackage = "github.com/matzefriedrich/parsley/pkg/features"
+	typesPackage    = "github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// inspect walks the syntax tree in depth-first order and passes the enclosing nodes of each node to the visit function.
+func inspect(root ast.Node, visit func(n ast.Node, stack []ast.Node)) {
+	stack := make([]ast.Node, 0)
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		visit(n, stack)
+		stack = append(stack, n)
+		return true
+	})
+}
+
+// calleeOf returns the package name and function name of a call of a package-level function, for instance, features and RegisterNamed for features.RegisterNamed[T](...).
+func calleeOf(call *ast.CallExpr) (string, string, bool) {
+	fun := call.Fun
+	switch expr := fun.(type) {
+	case *ast.IndexExpr:
+		fun = expr.X
+	case *ast.IndexListExpr:
+		fun = expr.X
+	}
+	selector, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	return ident.Name, selector.Sel.Name, true
+}
+
+// isPackageCall determines whether the given call invokes the specified function of the package imported by the file.
+func isPackageCall(f *File, call *ast.CallExpr, importPath string, name string) bool {
+	packageName, found := f.ImportName(importPath)
+	if !found {
+		return false
+	}
+	callPackageName, callName, ok := calleeOf(call)
+	return ok && callPackageName == packageName && callName == name
+}
+
+// isPackageType determines whether the given expression refers to the specified type of the package imported by the file; type arguments are ignored.
+func isPackageType(f *File, expr ast.Expr, importPath string, name string) bool {
+	packageName, found := f.ImportName(importPath)
+	if !found {
+		return false
+	}
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		expr = t.X
+	case *ast.IndexListExpr:
+		expr = t.X
+	}
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == packageName && selector.Sel.Name == name
+}
+
+// declaredType returns the type expression of the declaration of the given identifier, if the identifier refers to a parameter, a struct field, or a variable declared with an explicit type.
+func declaredType(ident *ast.Ident) (ast.Expr, bool) {
+	if ident.Obj == nil {
+		return nil, false
+	}
+	switch decl := ident.Obj.Decl.(type) {
+	case *ast.Field:
+		return decl.Type, true
+	case *ast.ValueSpec:
+		if decl.Type != nil {
+			return decl.Type, true
+		}
+	}
+	return nil, false
+}
+
+// isContextExpr determines whether the given expression is a context.Context value, for instance, a context parameter or the result of context.Background().
+func isContextExpr(f *File, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		packageName, found := f.ImportName(contextPackage)
+		callPackageName, _, ok := calleeOf(e)
+		return found && ok && callPackageName == packageName
+	case *ast.Ident:
+		if t, ok := declaredType(e); ok {
+			return isPackageType(f, t, contextPackage, "Context")
+		}
+		if e.Obj != nil {
+			if assign, ok := e.Obj.Decl.(*ast.AssignStmt); ok {
+				for i, lhs := range assign.Lhs {
+					if lhs == e && i < len(assign.Rhs) && len(assign.Lhs) == len(assign.Rhs) {
+						return isContextExpr(f, assign.Rhs[i])
+					}
+				}
+			}
+		}
+		return e.Name == "ctx"
+	}
+	return false
+}
+
+// contextExprFor returns an expression that provides a context.Context value at the position of the innermost node of the stack: either the name of a context parameter of an enclosing function, or a call of context.TODO(), in which case the context package gets imported.
+func contextExprFor(f *File, stack []ast.Node) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var funcType *ast.FuncType
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			funcType = n.Type
+		case *ast.FuncLit:
+			funcType = n.Type
+		default:
+			continue
+		}
+		for _, param := range funcType.Params.List {
+			if !isPackageType(f, param.Type, contextPackage, "Context") {
+				continue
+			}
+			for _, name := range param.Names {
+				if name.Name != "_" {
+					return name.Name
+				}
+			}
+		}
+	}
+	packageName, found := f.ImportName(contextPackage)
+	if !found {
+		packageName = contextPackage
+		f.AddImport(contextPackage)
+	}
+	return packageName + ".TODO()"
+}
@@ -4,11 +4,25 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 type AstFileSource struct {
 	File     *ast.File
 	Filename string
+	// Content holds the raw source code the syntax tree was parsed from.
+	Content []byte
+}
+
+// Text returns the source code between the positions of the given symbol.
+func (s *AstFileSource) Text(symbol SymbolInfo) string {
+	offset := func(pos token.Pos) int {
+		return int(pos - s.File.FileStart)
+	}
+	return string(s.Content[offset(symbol.Pos):offset(symbol.End)])
 }
 
 type AstFileAccessor func() (*AstFileSource, error)
@@ -16,9 +30,13 @@ type AstFileAccessor func() (*AstFileSource, error)
 // AstFromFile Creates an AstFileAccessor object for the given Golang source file.
 func AstFromFile(sourceFilePath string) AstFileAccessor {
 	return func() (*AstFileSource, error) {
+		content, err := os.ReadFile(sourceFilePath)
+		if err != nil {
+			return &AstFileSource{Filename: sourceFilePath}, err
+		}
 		fileSet := token.NewFileSet()
-		f, err := parser.ParseFile(fileSet, sourceFilePath, nil, parser.ParseComments)
-		source := &AstFileSource{File: f, Filename: sourceFilePath}
+		f, err := parser.ParseFile(fileSet, sourceFilePath, content, parser.ParseComments)
+		source := &AstFileSource{File: f, Filename: sourceFilePath, Content: content}
 		return source, err
 	}
 }
@@ -32,7 +50,51 @@ func AstFromSource(code []byte) AstFileAccessor {
 		if err != nil {
 			return nil, err
 		}
-		source := &AstFileSource{File: f, Filename: filename}
+		source := &AstFileSource{File: f, Filename: filename, Content: code}
 		return source, err
 	}
 }
+
+// AstPackageAccessor is a function type that provides the syntax trees of all source files of a package.
+type AstPackageAccessor func() ([]*AstFileSource, error)
+
+// AstPackageFromDirectory Creates an AstPackageAccessor object for all Golang source files in the given directory. Test files are skipped.
+func AstPackageFromDirectory(directoryPath string) AstPackageAccessor {
+	return func() ([]*AstFileSource, error) {
+		entries, err := os.ReadDir(directoryPath)
+		if err != nil {
+			return nil, err
+		}
+		sources := make([]*AstFileSource, 0)
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			source, sourceErr := AstFromFile(filepath.Join(directoryPath, name))()
+			if sourceErr != nil {
+				return nil, sourceErr
+			}
+			sources = append(sources, source)
+		}
+		sort.Slice(sources, func(i, j int) bool {
+			return sources[i].Filename < sources[j].Filename
+		})
+		return sources, nil
+	}
+}
+
+// AstPackageFromSources Creates an AstPackageAccessor object for the given source files.
+func AstPackageFromSources(code ...[]byte) AstPackageAccessor {
+	return func() ([]*AstFileSource, error) {
+		sources := make([]*AstFileSource, 0, len(code))
+		for _, c := range code {
+			source, err := AstFromSource(c)()
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, source)
+		}
+		return sources, nil
+	}
+}
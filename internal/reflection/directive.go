@@ -0,0 +1,88 @@
+package reflection
+
+import (
+	"slices"
+	"strings"
+)
+
+const directivePrefix = "//parsley:"
+
+// Directive represents a parsed //parsley:<name> comment, including its positional arguments and key-value options.
+// For example, the comment "//parsley:register singleton as=Greeter name=polite" yields a directive named "register"
+// with the argument "singleton" and the options "as" and "name".
+type Directive struct {
+	SymbolInfo
+	Name      string
+	Arguments []string
+	Options   map[string][]string
+}
+
+// ParseDirective tries to parse the given comment as a Parsley directive. Returns false if the comment is not a directive.
+func ParseDirective(comment Comment) (Directive, bool) {
+
+	commentText := strings.TrimSpace(comment.Text)
+	words := strings.Fields(commentText)
+	if len(words) == 0 || !strings.HasPrefix(words[0], directivePrefix) {
+		return Directive{}, false
+	}
+
+	name := strings.TrimPrefix(words[0], directivePrefix)
+	if name == "" {
+		return Directive{}, false
+	}
+
+	directive := Directive{
+		SymbolInfo: comment.SymbolInfo,
+		Name:       name,
+		Arguments:  make([]string, 0),
+		Options:    make(map[string][]string),
+	}
+
+	for _, word := range words[1:] {
+		key, value, isOption := strings.Cut(word, "=")
+		if !isOption {
+			directive.Arguments = append(directive.Arguments, word)
+			continue
+		}
+		values := make([]string, 0)
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+		directive.Options[key] = append(directive.Options[key], values...)
+	}
+
+	return directive, true
+}
+
+// Is returns true if the directive has the given name.
+func (d Directive) Is(name string) bool {
+	return d.Name == name
+}
+
+// Option returns the first value of the specified option, or an empty string if the option is not set.
+func (d Directive) Option(key string) string {
+	values := d.Options[key]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// HasArgument checks whether the directive has the given positional argument.
+func (d Directive) HasArgument(argument string) bool {
+	return slices.Contains(d.Arguments, argument)
+}
+
+// Directives returns all Parsley directives found in the model's comments.
+func (m *Model) Directives() []Directive {
+	directives := make([]Directive, 0)
+	for _, comment := range m.Comments {
+		if directive, ok := ParseDirective(comment); ok {
+			directives = append(directives, directive)
+		}
+	}
+	return directives
+}
@@ -2,6 +2,7 @@ package reflection
 
 import (
 	"go/ast"
+	"strconv"
 	"strings"
 )
 
@@ -9,18 +10,28 @@ type fileVisitor struct {
 	idSequence  uint64
 	packageName string
 	imports     []string
+	aliases     map[string]string
 	interfaces  []Interface
 	funcTypes   []FuncType
+	functions   []Function
+	structs     []Struct
+	valueTypes  []string
+	methodDecls []MethodDecl
 	comments    []Comment
 }
 
 func (t *fileVisitor) Model() (*Model, error) {
 	return &Model{
-		PackageName: t.packageName,
-		Imports:     t.imports,
-		Interfaces:  t.interfaces,
-		FuncTypes:   t.funcTypes,
-		Comments:    t.comments,
+		PackageName:   t.packageName,
+		Imports:       t.imports,
+		ImportAliases: t.aliases,
+		Interfaces:    t.interfaces,
+		FuncTypes:     t.funcTypes,
+		Functions:     t.functions,
+		Structs:       t.structs,
+		ValueTypes:    t.valueTypes,
+		MethodDecls:   t.methodDecls,
+		Comments:      t.comments,
 	}, nil
 }
 
@@ -29,11 +40,16 @@ var _ AstFileVisitor = (*fileVisitor)(nil)
 func NewFileVisitor() AstFileVisitor {
 	idSeed := 1
 	return &fileVisitor{
-		idSequence: uint64(idSeed),
-		imports:    make([]string, 0),
-		interfaces: make([]Interface, 0),
-		funcTypes:  make([]FuncType, 0),
-		comments:   make([]Comment, 0),
+		idSequence:  uint64(idSeed),
+		imports:     make([]string, 0),
+		aliases:     make(map[string]string),
+		interfaces:  make([]Interface, 0),
+		funcTypes:   make([]FuncType, 0),
+		functions:   make([]Function, 0),
+		structs:     make([]Struct, 0),
+		valueTypes:  make([]string, 0),
+		methodDecls: make([]MethodDecl, 0),
+		comments:    make([]Comment, 0),
 	}
 }
 
@@ -48,6 +64,8 @@ func (t *fileVisitor) VisitNode(n ast.Node) bool {
 		t.VisitImport(n)
 	case *ast.TypeSpec:
 		t.walkTypeSpecNode(n)
+	case *ast.FuncDecl:
+		t.VisitFuncDecl(n)
 	}
 	return true
 }
@@ -72,6 +90,9 @@ func (t *fileVisitor) VisitImport(importSpec *ast.ImportSpec) {
 	name := importSpec.Path.Value
 	name = strings.TrimSuffix(strings.TrimPrefix(name, "\""), "\"")
 	t.imports = append(t.imports, name)
+	if importSpec.Name != nil {
+		t.aliases[name] = importSpec.Name.Name
+	}
 }
 
 func (t *fileVisitor) VisitInterfaceType(name string, interfaceType *ast.InterfaceType) {
@@ -99,7 +120,135 @@ func (t *fileVisitor) VisitFuncType(name string, funcType *ast.FuncType) {
 	t.funcTypes = append(t.funcTypes, model)
 }
 
-func (t *fileVisitor) VisitStructType(_ string, _ *ast.StructType) {
+// VisitFuncDecl collects top-level function declarations; methods (functions with a receiver) are collected as method declarations.
+func (t *fileVisitor) VisitFuncDecl(funcDecl *ast.FuncDecl) {
+	doc := make([]Comment, 0)
+	if funcDecl.Doc != nil {
+		for _, comment := range funcDecl.Doc.List {
+			doc = append(doc, Comment{
+				SymbolInfo: SymbolInfo{Pos: comment.Pos(), End: comment.End()},
+				Text:       comment.Text,
+			})
+		}
+	}
+	if funcDecl.Recv != nil {
+		t.visitMethodDecl(funcDecl, doc)
+		return
+	}
+	model := Function{
+		SymbolInfo: SymbolInfo{
+			Id:  t.newSymbolId(),
+			Pos: funcDecl.Pos(),
+			End: funcDecl.End(),
+		},
+		Name:       funcDecl.Name.Name,
+		Parameters: CollectParametersFor(funcDecl.Type),
+		Results:    CollectResultFieldsFor(funcDecl.Type),
+		Doc:        doc,
+	}
+	t.functions = append(t.functions, model)
+}
+
+func (t *fileVisitor) visitMethodDecl(funcDecl *ast.FuncDecl, doc []Comment) {
+	if len(funcDecl.Recv.List) != 1 {
+		return
+	}
+	model := MethodDecl{
+		Method: Method{
+			SymbolInfo: SymbolInfo{
+				Id:  t.newSymbolId(),
+				Pos: funcDecl.Pos(),
+				End: funcDecl.End(),
+			},
+			Name:       funcDecl.Name.Name,
+			Parameters: CollectParametersFor(funcDecl.Type),
+			Results:    CollectResultFieldsFor(funcDecl.Type),
+		},
+		Doc: doc,
+		Signature: SymbolInfo{
+			Pos: funcDecl.Type.Params.Pos(),
+			End: funcDecl.Type.End(),
+		},
+	}
+	receiverType := funcDecl.Recv.List[0].Type
+	if star, ok := receiverType.(*ast.StarExpr); ok {
+		model.PointerReceiver = true
+		receiverType = star.X
+	}
+	switch expr := receiverType.(type) {
+	case *ast.IndexExpr:
+		model.GenericReceiver = true
+		receiverType = expr.X
+	case *ast.IndexListExpr:
+		model.GenericReceiver = true
+		receiverType = expr.X
+	}
+	ident, ok := receiverType.(*ast.Ident)
+	if !ok {
+		return
+	}
+	model.ReceiverType = ident.Name
+	t.methodDecls = append(t.methodDecls, model)
+}
+
+func (t *fileVisitor) VisitStructType(name string, structType *ast.StructType) {
+	model := Struct{
+		SymbolInfo: SymbolInfo{
+			Id:  t.newSymbolId(),
+			Pos: structType.Pos(),
+			End: structType.End(),
+		},
+		Name:   name,
+		Fields: make([]Field, 0),
+	}
+	for _, field := range structType.Fields.List {
+		t.visitStructField(&model, field)
+	}
+	t.structs = append(t.structs, model)
+}
+
+func (t *fileVisitor) visitStructField(model *Struct, field *ast.Field) {
+	tag := ""
+	if field.Tag != nil {
+		tag, _ = strconv.Unquote(field.Tag.Value)
+	}
+	newField := func(name string, embedded bool) Field {
+		return Field{
+			SymbolInfo: SymbolInfo{
+				Id:  t.newSymbolId(),
+				Pos: field.Pos(),
+				End: field.End(),
+			},
+			Name:     name,
+			Embedded: embedded,
+			Tag:      tag,
+			Type:     SymbolInfo{Pos: field.Type.Pos(), End: field.Type.End()},
+		}
+	}
+	if len(field.Names) == 0 {
+		model.Fields = append(model.Fields, newField(embeddedFieldName(field.Type), true))
+		return
+	}
+	for _, name := range field.Names {
+		model.Fields = append(model.Fields, newField(name.Name, false))
+	}
+}
+
+// embeddedFieldName returns the implicit name of an embedded field, that is, the name of its type without pointer, package qualifier, and type arguments.
+func embeddedFieldName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return embeddedFieldName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedFieldName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(e.X)
+	}
+	return ""
 }
 
 func (t *fileVisitor) walkTypeSpecNode(spec *ast.TypeSpec) {
@@ -114,6 +263,16 @@ func (t *fileVisitor) walkTypeSpecNode(spec *ast.TypeSpec) {
 	case *ast.StructType:
 		structType, _ := spec.Type.(*ast.StructType)
 		t.VisitStructType(typeName, structType)
+		t.structs[len(t.structs)-1].Generic = spec.TypeParams != nil
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType:
+		if !spec.Assign.IsValid() {
+			t.valueTypes = append(t.valueTypes, typeName)
+		}
+	case *ast.Ident:
+		underlyingType, _ := spec.Type.(*ast.Ident)
+		if !spec.Assign.IsValid() && IsBuiltinTypeName(underlyingType.Name) {
+			t.valueTypes = append(t.valueTypes, typeName)
+		}
 	}
 }
 
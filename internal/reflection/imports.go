@@ -0,0 +1,93 @@
+package reflection
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PackageNameFromImportPath returns the package name assumed for the given import path. The name is derived from the last path element;
+// major version suffixes, such as v2, are skipped, a "go-" prefix is removed, and the name is cut at the first character that is not valid in an identifier.
+func PackageNameFromImportPath(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// ImportName returns the name under which the given import path is referenced in the source file, which is either its explicit alias or the assumed package name.
+func (m *Model) ImportName(importPath string) string {
+	if alias, found := m.ImportAliases[importPath]; found {
+		return alias
+	}
+	return PackageNameFromImportPath(importPath)
+}
+
+// resolveImportConflict renames imports of the source file that are referenced under the same name as the given import path.
+// The conflicting import gets a unique alias, and all type references of the model are updated accordingly.
+func (m *Model) resolveImportConflict(importPath string) {
+	name := PackageNameFromImportPath(importPath)
+	for _, existingPath := range m.Imports {
+		if existingPath == importPath || m.ImportName(existingPath) != name {
+			continue
+		}
+		alias := name
+		for n := 1; m.isImportNameInUse(alias); n++ {
+			alias = fmt.Sprintf("%s%d", name, n)
+		}
+		if m.ImportAliases == nil {
+			m.ImportAliases = make(map[string]string)
+		}
+		m.ImportAliases[existingPath] = alias
+		m.renameSelectors(name, alias)
+	}
+}
+
+func (m *Model) isImportNameInUse(name string) bool {
+	for _, importPath := range m.Imports {
+		if m.ImportName(importPath) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// renameSelectors replaces the package selector of all type references in the model.
+func (m *Model) renameSelectors(from string, to string) {
+	rename := func(parameters []Parameter) {
+		for _, p := range parameters {
+			for t := p.Type; t != nil; t = t.Next {
+				if t.SelectorName == from {
+					t.SelectorName = to
+				}
+			}
+		}
+	}
+	for _, i := range m.Interfaces {
+		for _, method := range i.Methods {
+			rename(method.Parameters)
+			rename(method.Results)
+		}
+	}
+	for _, f := range m.FuncTypes {
+		rename(f.Parameters)
+		rename(f.Results)
+	}
+	for _, f := range m.Functions {
+		rename(f.Parameters)
+		rename(f.Results)
+	}
+}
@@ -3,6 +3,8 @@ package reflection
 import (
 	"fmt"
 	"go/ast"
+	"go/parser"
+	"slices"
 
 	"github.com/matzefriedrich/parsley/internal"
 )
@@ -33,6 +35,13 @@ func CollectParametersFor(funcType *ast.FuncType) []Parameter {
 	parameters := make([]Parameter, 0)
 	for _, param := range funcType.Params.List {
 		typeInfo := getFieldTypeInfo(param)
+		if len(param.Names) == 0 {
+			parameters = append(parameters, Parameter{
+				Name: fmt.Sprintf("arg%d", len(parameters)),
+				Type: typeInfo,
+			})
+			continue
+		}
 		for _, paramName := range param.Names {
 			parameters = append(parameters, Parameter{
 				Name: paramName.Name,
@@ -132,3 +141,27 @@ func getFieldTypeInfo(param *ast.Field) *ParameterType {
 
 	return result
 }
+
+// ParseTypeExpression parses the given type expression, for instance, "*http.Client", into a ParameterType.
+func ParseTypeExpression(s string) (*ParameterType, error) {
+	expr, err := parser.ParseExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	typeInfo := getFieldTypeInfo(&ast.Field{Type: expr})
+	if typeInfo == nil {
+		return nil, fmt.Errorf("unsupported type expression: %s", s)
+	}
+	return typeInfo, nil
+}
+
+var builtinTypeNames = []string{
+	"bool", "byte", "complex64", "complex128", "float32", "float64",
+	"int", "int8", "int16", "int32", "int64", "rune", "string",
+	"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+}
+
+// IsBuiltinTypeName checks whether the given name refers to one of the predeclared basic types, such as int or string.
+func IsBuiltinTypeName(name string) bool {
+	return slices.Contains(builtinTypeNames, name)
+}
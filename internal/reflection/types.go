@@ -47,6 +47,8 @@ type Method struct {
 	Name       string
 	Parameters []Parameter
 	Results    []Parameter
+	// Excluded is set if a directive option excludes the method from code generation; generators may still emit a pass-through implementation.
+	Excluded bool
 }
 
 type Interface struct {
@@ -70,6 +72,71 @@ type FuncType struct {
 	Results    []Parameter
 }
 
+// AsMethod returns a Method with the given name and the signature of the func type, so that it can be passed to template functions that format method signatures.
+func (f FuncType) AsMethod(name string) Method {
+	return Method{
+		SymbolInfo: f.SymbolInfo,
+		Name:       name,
+		Parameters: f.Parameters,
+		Results:    f.Results,
+	}
+}
+
+// Function represents a top-level function declaration, such as a constructor function.
+type Function struct {
+	SymbolInfo
+	Name       string
+	Parameters []Parameter
+	Results    []Parameter
+	Doc        []Comment
+}
+
+// Directives returns all Parsley directives found in the function's doc comment.
+func (f Function) Directives() []Directive {
+	directives := make([]Directive, 0)
+	for _, comment := range f.Doc {
+		if directive, ok := ParseDirective(comment); ok {
+			directives = append(directives, directive)
+		}
+	}
+	return directives
+}
+
+// Struct represents a struct type declaration.
+type Struct struct {
+	SymbolInfo
+	Name   string
+	Fields []Field
+	// Generic is set if the struct type has type parameters.
+	Generic bool
+}
+
+// Field represents a field of a struct type. Fields declared with multiple names yield a Field for each name.
+type Field struct {
+	SymbolInfo
+	// Name is the name of the field; for embedded fields, the name of the embedded type without package qualifier.
+	Name     string
+	Embedded bool
+	// Tag holds the unquoted tag of the field, if any.
+	Tag string
+	// Type holds the positions of the field's type expression in the source file.
+	Type SymbolInfo
+}
+
+// MethodDecl represents a method declaration, that is, a function with a receiver.
+type MethodDecl struct {
+	Method
+	// ReceiverType is the name of the receiver's base type, without pointer and type parameters.
+	ReceiverType string
+	// PointerReceiver is set if the method has a pointer receiver.
+	PointerReceiver bool
+	// GenericReceiver is set if the receiver's base type has type parameters.
+	GenericReceiver bool
+	Doc             []Comment
+	// Signature holds the positions of the parameter list and the results of the method in the source file.
+	Signature SymbolInfo
+}
+
 type Comment struct {
 	SymbolInfo
 	Text string
@@ -77,16 +144,25 @@ type Comment struct {
 
 // Model is the generator root model type.
 type Model struct {
-	Comments    []Comment
-	Interfaces  []Interface
-	FuncTypes   []FuncType
+	Comments   []Comment
+	Interfaces []Interface
+	FuncTypes  []FuncType
+	Functions  []Function
+	Structs    []Struct
+	// ValueTypes holds the names of the declared types that are neither interface, func, nor struct types; for instance, named basic, slice, or map types. Type aliases are not included.
+	ValueTypes  []string
+	MethodDecls []MethodDecl
 	PackageName string
 	Imports     []string
+	// ImportAliases maps import paths to the explicit names they are imported with in the source file.
+	ImportAliases map[string]string
 }
 
 type ModelConfigurationFunc func(m *Model)
 
+// AddImport adds the given import path to the model. If the source file references another package under the same name, that import gets a unique alias.
 func (m *Model) AddImport(s string) {
 	s = strings.TrimSuffix(strings.TrimPrefix(s, "\""), "\"")
+	m.resolveImportConflict(s)
 	m.Imports = append(m.Imports, s)
 }
@@ -16,6 +16,7 @@ type AstFileVisitor interface {
 	VisitInterfaceType(name string, interfaceType *ast.InterfaceType)
 	VisitFuncType(name string, funcType *ast.FuncType)
 	VisitStructType(name string, structType *ast.StructType)
+	VisitFuncDecl(funcDecl *ast.FuncDecl)
 	Model() (*Model, error)
 }
 
@@ -8,8 +8,26 @@ import (
 //go:embed generator/method_interception.gotmpl
 var ProxyTemplate string
 
+//go:embed generator/adapter.gotmpl
+var AdapterTemplate string
+
+//go:embed generator/decorator.gotmpl
+var DecoratorTemplate string
+
 //go:embed generator/mocks.gotmpl
 var MockTemplate string
 
+//go:embed generator/module.gotmpl
+var ModuleTemplate string
+
+//go:embed generator/wiring.gotmpl
+var WiringTemplate string
+
+//go:embed generator/interface.gotmpl
+var InterfaceTemplate string
+
+//go:embed generator/constructor.gotmpl
+var ConstructorTemplate string
+
 //go:embed bootstrap/*
 var BootstrapTemplates embed.FS
@@ -0,0 +1,32 @@
+package analyzers
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/analyzers"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test_ActivatorFuncAnalyzer_reports_invalid_activator_functions(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzers.ActivatorFuncAnalyzer, "activator")
+}
+
+func Test_ContextParameterAnalyzer_reports_context_parameter_not_in_first_position(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzers.ContextParameterAnalyzer, "contextparameter")
+}
+
+func Test_RegistrationErrorAnalyzer_reports_unchecked_registration_errors(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzers.RegistrationErrorAnalyzer, "registererror")
+}
+
+func Test_ServiceTypeAnalyzer_reports_unsupported_service_types(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzers.ServiceTypeAnalyzer, "servicetype")
+}
+
+func Test_Analyzers_are_valid(t *testing.T) {
+	err := analysis.Validate(analyzers.Analyzers())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
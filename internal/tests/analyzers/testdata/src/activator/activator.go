@@ -0,0 +1,87 @@
+package activator
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+type Greeter interface {
+	SayHello(name string) string
+}
+
+type greeter struct{}
+
+func (g *greeter) SayHello(name string) string {
+	return "Hello, " + name
+}
+
+func NewGreeter() Greeter {
+	return &greeter{}
+}
+
+func NewGreeterWithOptions(prefix string) (Greeter, error) {
+	return &greeter{}, nil
+}
+
+func NewPoliteGreeter(prefix string) Greeter {
+	return &greeter{}
+}
+
+func NewGreeterName() string {
+	return "greeter"
+}
+
+func NewGreeters() (Greeter, Greeter, error) {
+	return nil, nil, nil
+}
+
+func NewGreeterList() []Greeter {
+	return nil
+}
+
+func NewGreeterWithFlag() (Greeter, bool) {
+	return nil, false
+}
+
+func Configure(registry types.ServiceRegistry) error {
+	return registration.RegisterSingleton(registry,
+		NewGreeter,
+		NewGreeterWithOptions,
+		NewGreeter(),       // want `NewGreeter\(\) is not an activator function; pass the activator function NewGreeter instead of calling it`
+		&greeter{},         // want `&greeter\{\} is not an activator function; pass a function that returns the \*greeter service, or use registration.RegisterInstance to register an existing value`
+		NewGreeterName,     // want `NewGreeterName is not a valid activator function: the service type string must be a pointer, interface, function, or struct type`
+		NewGreeters,        // want `NewGreeters is not a valid activator function: the function must return a service and optionally an error`
+		NewGreeterWithFlag, // want `NewGreeterWithFlag is not a valid activator function: the second return value must be an error`
+		func() {},          // want `func\(\) \{\} is not a valid activator function: the function does not return a service`
+	)
+}
+
+func ConfigureRegistry(registry types.ServiceRegistry) error {
+	err := registry.Register(NewGreeter, types.LifetimeScoped)
+	if err != nil {
+		return err
+	}
+	err = registry.Register(nil, types.LifetimeScoped) // want `nil is not an activator function`
+	if err != nil {
+		return err
+	}
+	err = features.RegisterLazy[Greeter](registry, NewPoliteGreeter("Hi"), types.LifetimeTransient) // want `NewPoliteGreeter\("Hi"\) is not an activator function`
+	if err != nil {
+		return err
+	}
+	return features.RegisterNamed[Greeter](registry,
+		registration.NamedServiceRegistration("polite", NewGreeter, types.LifetimeSingleton),
+		registration.NamedServiceRegistration("rude", "rude", types.LifetimeSingleton)) // want `^"rude" is not an activator function$`
+}
+
+func Register(registry types.ServiceRegistry, activatorFunc any) error {
+	return registration.RegisterTransient(registry, activatorFunc, NewGreeterList)
+}
+
+func Activate[T any](ctx context.Context, resolver types.Resolver, activatorFunc func() T) (T, error) {
+	return resolving.Activate[T](ctx, resolver, activatorFunc)
+}
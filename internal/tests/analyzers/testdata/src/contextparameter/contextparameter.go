@@ -0,0 +1,37 @@
+package contextparameter
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+type Repository interface {
+	Find(ctx context.Context, id string) (string, error)
+}
+
+type Service struct {
+	repository Repository
+}
+
+func NewService(ctx context.Context, repository Repository) *Service {
+	return &Service{repository: repository}
+}
+
+func NewServiceWithContextLast(repository Repository, ctx context.Context) *Service {
+	return &Service{repository: repository}
+}
+
+func Configure(registry types.ServiceRegistry) error {
+	return registration.RegisterScoped(registry,
+		NewService,
+		NewServiceWithContextLast, // want `context.Context is parameter 2 of the activator function; Parsley passes the context only to the first parameter and tries to resolve any other context.Context parameter as a service`
+		func(repository Repository, ctx context.Context) *Service { // want `context.Context is parameter 2 of the activator function`
+			return &Service{repository: repository}
+		},
+		func(a, b context.Context) *Service { // want `context.Context is parameter 2 of the activator function`
+			return &Service{}
+		},
+	)
+}
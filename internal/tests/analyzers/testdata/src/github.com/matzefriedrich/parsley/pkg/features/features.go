@@ -0,0 +1,22 @@
+package features
+
+import (
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+func RegisterList[T any](registry types.ServiceRegistry) error {
+	return nil
+}
+
+func RegisterLazy[T any](registry types.ServiceRegistry, activatorFunc any, scope types.LifetimeScope) error {
+	return nil
+}
+
+func RegisterNamed[T any](registry types.ServiceRegistry, services ...registration.NamedServiceRegistrationFunc) error {
+	return nil
+}
+
+func RegisterFactory[T any](registry types.ServiceRegistry, scope types.LifetimeScope) error {
+	return nil
+}
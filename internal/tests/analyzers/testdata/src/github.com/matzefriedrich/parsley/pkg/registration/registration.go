@@ -0,0 +1,29 @@
+package registration
+
+import "github.com/matzefriedrich/parsley/pkg/types"
+
+type SupportsRegisterActivatorFunc interface {
+	Register(activatorFunc any, scope types.LifetimeScope) error
+}
+
+func RegisterTransient(registry SupportsRegisterActivatorFunc, activatorFunc ...any) error {
+	return nil
+}
+
+func RegisterScoped(registry SupportsRegisterActivatorFunc, activatorFunc ...any) error {
+	return nil
+}
+
+func RegisterSingleton(registry SupportsRegisterActivatorFunc, activatorFunc ...any) error {
+	return nil
+}
+
+func RegisterInstance[T any](registry types.ServiceRegistry, instance T) error {
+	return nil
+}
+
+type NamedServiceRegistrationFunc func() (name string, activatorFunc any, scope types.LifetimeScope)
+
+func NamedServiceRegistration(name string, activatorFunc any, scope types.LifetimeScope) NamedServiceRegistrationFunc {
+	return nil
+}
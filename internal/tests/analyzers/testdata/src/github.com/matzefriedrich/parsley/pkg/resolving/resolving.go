@@ -0,0 +1,21 @@
+package resolving
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+func ResolveRequiredServices[T any](ctx context.Context, resolver types.Resolver) ([]T, error) {
+	return nil, nil
+}
+
+func ResolveRequiredService[T any](ctx context.Context, resolver types.Resolver) (T, error) {
+	var nilInstance T
+	return nilInstance, nil
+}
+
+func Activate[T any](ctx context.Context, resolver types.Resolver, activatorFunc any, options ...types.ResolverOptionsFunc) (T, error) {
+	var nilInstance T
+	return nilInstance, nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import "context"
+
+type LifetimeScope uint
+
+const (
+	LifetimeTransient LifetimeScope = iota
+	LifetimeScoped
+	LifetimeSingleton
+)
+
+type ServiceType interface {
+	Name() string
+}
+
+type ModuleFunc func(registry ServiceRegistry) error
+
+type ServiceRegistry interface {
+	Register(activatorFunc any, scope LifetimeScope) error
+	RegisterModule(modules ...ModuleFunc) error
+}
+
+type Resolver interface {
+	Resolve(ctx context.Context, serviceType ServiceType) ([]any, error)
+}
+
+type ResolverOptionsFunc func(registry ServiceRegistry) error
+
+func MakeServiceType[T any]() ServiceType {
+	return nil
+}
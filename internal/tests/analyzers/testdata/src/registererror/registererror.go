@@ -0,0 +1,39 @@
+package registererror
+
+import (
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+type Greeter interface {
+	SayHello(name string) string
+}
+
+func NewGreeter() Greeter {
+	return nil
+}
+
+func Configure(registry types.ServiceRegistry) error {
+	registration.RegisterSingleton(registry, NewGreeter) // want `the error returned by registration.RegisterSingleton is not checked`
+	_ = features.RegisterList[Greeter](registry)
+	registry.RegisterModule(Configure) // want `the error returned by ServiceRegistry.RegisterModule is not checked`
+	return nil
+}
+
+func ConfigureChecked(registry types.ServiceRegistry) error {
+	err := registry.Register(NewGreeter, types.LifetimeTransient)
+	if err != nil {
+		return err
+	}
+	return registration.RegisterInstance(registry, NewGreeter())
+}
+
+func main() {
+	var registry types.ServiceRegistry
+	registration.RegisterTransient(registry, NewGreeter) // want `the error returned by registration.RegisterTransient is not checked`
+	_ = func() error {
+		registry.Register(NewGreeter, types.LifetimeTransient) // want `the error returned by ServiceRegistry.Register is not checked`
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package servicetype
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+type Greeter interface {
+	SayHello(name string) string
+}
+
+type Settings map[string]string
+
+type Options struct {
+	Name string
+}
+
+func Resolve(ctx context.Context, resolver types.Resolver) error {
+	_, _ = resolving.ResolveRequiredService[Greeter](ctx, resolver)
+	_, _ = resolving.ResolveRequiredService[*Options](ctx, resolver)
+	_, _ = resolving.ResolveRequiredService[Options](ctx, resolver)
+	_, _ = resolving.ResolveRequiredService[[]Greeter](ctx, resolver)
+	_, _ = resolving.ResolveRequiredService[Settings](ctx, resolver) // want `Settings is not a supported service type of resolving.ResolveRequiredService; services must be of a pointer, interface, function, or struct type`
+	_, _ = resolving.ResolveRequiredServices[int](ctx, resolver)     // want `int is not a supported service type of resolving.ResolveRequiredServices`
+	_ = types.MakeServiceType[string]()                              // want `string is not a supported service type of types.MakeServiceType`
+	return nil
+}
+
+func Configure(registry types.ServiceRegistry) error {
+	if err := features.RegisterList[Greeter](registry); err != nil {
+		return err
+	}
+	if err := features.RegisterList[[]Greeter](registry); err != nil { // want `\[\]Greeter is not a supported service type of features.RegisterList`
+		return err
+	}
+	return registration.RegisterInstance(registry, Settings{}) // want `Settings is not a supported service type of registration.RegisterInstance`
+}
+
+func Generic[T any](ctx context.Context, resolver types.Resolver) (T, error) {
+	return resolving.ResolveRequiredService[T](ctx, resolver)
+}
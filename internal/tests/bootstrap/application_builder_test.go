@@ -0,0 +1,139 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/bootstrap"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApplicationBuilder_Build_propagates_module_errors(t *testing.T) {
+
+	// Arrange
+	moduleErr := errors.New("missing connection string")
+	sut := bootstrap.NewApplicationBuilder(newTestApp).
+		WithModules(func(registry types.ServiceRegistry) error {
+			return moduleErr
+		})
+
+	// Act
+	host, err := sut.Build()
+
+	// Assert
+	assert.Nil(t, host)
+	assert.ErrorIs(t, err, bootstrap.ErrCannotConfigureModules)
+	assert.ErrorIs(t, err, moduleErr)
+}
+
+func Test_ApplicationBuilder_Build_strict_validation_fails_for_missing_registrations(t *testing.T) {
+
+	// Arrange
+	appFactory := func(_ *database) bootstrap.Application {
+		return newTestApp()
+	}
+	sut := bootstrap.NewApplicationBuilder(appFactory).WithValidation(bootstrap.ValidationStrict)
+
+	// Act
+	host, err := sut.Build()
+
+	// Assert
+	assert.Nil(t, host)
+	assert.ErrorIs(t, err, bootstrap.ErrInvalidRegistry)
+	assert.ErrorIs(t, err, registration.ErrRegistryMissesRequiredServiceRegistrations)
+}
+
+func Test_ApplicationBuilder_Build_lenient_validation_reports_errors_to_observers(t *testing.T) {
+
+	// Arrange
+	appFactory := func(_ *database) bootstrap.Application {
+		return newTestApp()
+	}
+	events := make([]bootstrap.LifecycleEvent, 0)
+	sut := bootstrap.NewApplicationBuilder(appFactory).
+		WithValidation(bootstrap.ValidationLenient).
+		WithObserver(func(event bootstrap.LifecycleEvent) {
+			events = append(events, event)
+		})
+
+	// Act
+	host, err := sut.Build()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, host)
+	assert.Len(t, events, 1)
+	assert.Equal(t, bootstrap.EventRegistryValidated, events[0].Kind)
+	assert.ErrorIs(t, events[0].Err, registration.ErrRegistryMissesRequiredServiceRegistrations)
+}
+
+func Test_ApplicationBuilder_Build_exposes_registry_and_resolver(t *testing.T) {
+
+	// Arrange
+	appFactory := func(_ types.Resolver, _ *database) bootstrap.Application {
+		return newTestApp()
+	}
+	sut := bootstrap.NewApplicationBuilder(appFactory).
+		WithModules(func(registry types.ServiceRegistry) error {
+			return registration.RegisterSingleton(registry, func() *database { return &database{} })
+		}).
+		WithValidation(bootstrap.ValidationStrict)
+
+	// Act
+	host, err := sut.Build()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, host.Registry().IsRegistered(types.MakeServiceType[*database]()))
+	db, resolveErr := resolving.ResolveRequiredService[*database](t.Context(), host.Resolver())
+	assert.NoError(t, resolveErr)
+	assert.NotNil(t, db)
+}
+
+func Test_Host_Run_notifies_observers_about_lifecycle_events(t *testing.T) {
+
+	// Arrange
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			cancel()
+			return nil
+		}}
+	}
+
+	serviceEvents := make([]string, 0)
+	kinds := make([]bootstrap.LifecycleEventKind, 0)
+	host, _ := bootstrap.NewApplicationBuilder(appFactory).
+		WithModules(func(registry types.ServiceRegistry) error {
+			_ = registration.RegisterSingleton(registry, func() *database { return &database{hostedService: newHostedService("database", &serviceEvents)} })
+			return registration.RegisterSingleton(registry, func(service *database) bootstrap.HostedService { return service })
+		}).
+		WithObserver(func(event bootstrap.LifecycleEvent) {
+			kinds = append(kinds, event.Kind)
+		}).
+		Build()
+
+	// Act
+	err := host.Run(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []bootstrap.LifecycleEventKind{
+		bootstrap.EventHostedServiceStarted,
+		bootstrap.EventApplicationStarted,
+		bootstrap.EventApplicationStopped,
+		bootstrap.EventHostedServiceStopped,
+	}, kinds)
+}
+
+func newTestApp() bootstrap.Application {
+	return &testApp{RunFunc: func(ctx context.Context) error {
+		return nil
+	}}
+}
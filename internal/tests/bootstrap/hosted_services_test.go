@@ -0,0 +1,174 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matzefriedrich/parsley/pkg/bootstrap"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunParsleyApplication_starts_hosted_services_in_dependency_order_and_stops_them_in_reverse_order(t *testing.T) {
+
+	// Arrange
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	events := make([]string, 0)
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			events = append(events, "run")
+			cancel()
+			return nil
+		}}
+	}
+
+	// Act
+	err := bootstrap.RunParsleyApplication(ctx, appFactory, func(registry types.ServiceRegistry) error {
+		_ = registration.RegisterSingleton(registry, func(db *database) *api { return &api{hostedService: newHostedService("api", &events)} })
+		_ = registration.RegisterSingleton(registry, func() *database { return &database{hostedService: newHostedService("database", &events)} })
+		_ = registration.RegisterSingleton(registry, func(service *api) bootstrap.HostedService { return service })
+		return registration.RegisterSingleton(registry, func(service *database) bootstrap.HostedService { return service })
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start database", "start api", "run", "stop api", "stop database"}, events)
+}
+
+func Test_RunParsleyApplication_cancels_context_on_interrupt_if_hosted_services_are_registered(t *testing.T) {
+
+	// Arrange
+	events := make([]string, 0)
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			process, _ := os.FindProcess(os.Getpid())
+			if err := process.Signal(os.Interrupt); err != nil {
+				t.Skip("sending signals is not supported on this platform")
+			}
+			select {
+			case <-ctx.Done():
+				events = append(events, "canceled")
+			case <-time.After(5 * time.Second):
+			}
+			return nil
+		}}
+	}
+
+	// Act
+	err := bootstrap.RunParsleyApplication(t.Context(), appFactory, func(registry types.ServiceRegistry) error {
+		return registration.RegisterSingleton(registry, func() bootstrap.HostedService { return newHostedService("worker", &events) })
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start worker", "canceled", "stop worker"}, events)
+}
+
+func Test_RunParsleyApplication_stops_started_hosted_services_if_a_service_fails_to_start(t *testing.T) {
+
+	// Arrange
+	startErr := errors.New("port in use")
+	events := make([]string, 0)
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			events = append(events, "run")
+			return nil
+		}}
+	}
+
+	// Act
+	err := bootstrap.RunParsleyApplication(t.Context(), appFactory, func(registry types.ServiceRegistry) error {
+		_ = registration.RegisterSingleton(registry, func() *database { return &database{hostedService: newHostedService("database", &events)} })
+		_ = registration.RegisterSingleton(registry, func() *api {
+			s := newHostedService("api", &events)
+			s.startErr = startErr
+			return &api{hostedService: s}
+		})
+		_ = registration.RegisterSingleton(registry, func(service *database) bootstrap.HostedService { return service })
+		return registration.RegisterSingleton(registry, func(service *api) bootstrap.HostedService { return service })
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, bootstrap.ErrCannotStartHostedServices)
+	assert.ErrorIs(t, err, startErr)
+	assert.Equal(t, []string{"start database", "start api", "stop database"}, events)
+}
+
+func Test_RunParsleyApplication_aggregates_run_and_shutdown_errors_within_shutdown_timeout(t *testing.T) {
+
+	// Arrange
+	runErr := errors.New("run failed")
+	stopErr := errors.New("flush failed")
+	events := make([]string, 0)
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			return runErr
+		}}
+	}
+
+	// Act
+	started := time.Now()
+	err := bootstrap.RunParsleyApplication(t.Context(), appFactory, bootstrap.WithShutdownTimeout(10*time.Millisecond), func(registry types.ServiceRegistry) error {
+		_ = registration.RegisterSingleton(registry, func() *database {
+			s := newHostedService("database", &events)
+			s.stopErr = stopErr
+			return &database{hostedService: s}
+		})
+		_ = registration.RegisterSingleton(registry, func() *api {
+			s := newHostedService("api", &events)
+			s.stopDelay = time.Second
+			return &api{hostedService: s}
+		})
+		_ = registration.RegisterSingleton(registry, func(service *api) bootstrap.HostedService { return service })
+		return registration.RegisterSingleton(registry, func(service *database) bootstrap.HostedService { return service })
+	})
+
+	// Assert
+	assert.Less(t, time.Since(started), time.Second)
+	assert.ErrorIs(t, err, runErr)
+	assert.ErrorIs(t, err, bootstrap.ErrCannotStopHostedServices)
+	assert.ErrorIs(t, err, stopErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type hostedService struct {
+	name      string
+	events    *[]string
+	startErr  error
+	stopErr   error
+	stopDelay time.Duration
+}
+
+func newHostedService(name string, events *[]string) *hostedService {
+	return &hostedService{name: name, events: events}
+}
+
+func (s *hostedService) Start(_ context.Context) error {
+	*s.events = append(*s.events, "start "+s.name)
+	return s.startErr
+}
+
+func (s *hostedService) Stop(_ context.Context) error {
+	if s.stopDelay > 0 {
+		time.Sleep(s.stopDelay)
+		return nil
+	}
+	*s.events = append(*s.events, "stop "+s.name)
+	return s.stopErr
+}
+
+type database struct {
+	*hostedService
+}
+
+type api struct {
+	*hostedService
+}
+
+var _ bootstrap.HostedService = (*database)(nil)
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/bootstrap"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScopeFrom_provides_resolver_and_registry_of_the_application(t *testing.T) {
+
+	// Arrange
+	var resolver types.Resolver
+	var registry types.ServiceRegistry
+	var resolverFound, registryFound bool
+
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			resolver, resolverFound = bootstrap.ResolverFrom(ctx)
+			registry, registryFound = bootstrap.RegistryFrom(ctx)
+			return nil
+		}}
+	}
+
+	host, _ := bootstrap.NewApplicationBuilder(appFactory).Build()
+
+	// Act
+	err := host.Run(t.Context())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, resolverFound)
+	assert.Same(t, host.Resolver(), resolver)
+	assert.True(t, registryFound)
+	assert.Same(t, host.Registry(), registry)
+}
+
+func Test_ScopeFrom_returns_false_for_contexts_not_derived_from_an_application(t *testing.T) {
+
+	// Act
+	scope, found := bootstrap.ScopeFrom(t.Context())
+	_, resolverFound := bootstrap.ResolverFrom(t.Context())
+
+	// Assert
+	assert.Nil(t, scope)
+	assert.False(t, found)
+	assert.False(t, resolverFound)
+}
+
+func Test_Scope_NewScope_creates_scopes_that_do_not_share_scoped_instances(t *testing.T) {
+
+	// Arrange
+	type requestKey struct{}
+	instances := make([]*database, 0)
+
+	appFactory := func() bootstrap.Application {
+		return &testApp{RunFunc: func(ctx context.Context) error {
+			scope, _ := bootstrap.ScopeFrom(ctx)
+			for _, request := range []string{"a", "b"} {
+				requestScope := scope.NewScope(context.WithValue(t.Context(), requestKey{}, request))
+				first, _ := resolving.ResolveRequiredService[*database](requestScope.Context(), requestScope.Resolver())
+				second, _ := resolving.ResolveRequiredService[*database](requestScope.Context(), requestScope.Resolver())
+				assert.Same(t, first, second)
+				assert.Equal(t, request, requestScope.Context().Value(requestKey{}))
+				_, nested := bootstrap.ScopeFrom(requestScope.Context())
+				assert.True(t, nested)
+				instances = append(instances, first)
+			}
+			return nil
+		}}
+	}
+
+	host, _ := bootstrap.NewApplicationBuilder(appFactory).
+		WithModules(func(registry types.ServiceRegistry) error {
+			return registration.RegisterScoped(registry, func() *database { return &database{} })
+		}).
+		Build()
+
+	// Act
+	err := host.Run(t.Context())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, instances, 2)
+	assert.NotSame(t, instances[0], instances[1])
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/cli"
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRootCommand_adds_commands_of_all_registered_command_services(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterTransient(registry, newServeCommand, newMigrateCommand)
+
+	// Act
+	root, err := cli.NewRootCommand(resolving.NewResolver(registry), &cobra.Command{Use: "app"})
+
+	// Assert
+	assert.NoError(t, err)
+	names := make([]string, 0)
+	for _, command := range root.Commands() {
+		names = append(names, command.Name())
+	}
+	assert.Equal(t, []string{"migrate", "serve"}, names)
+}
+
+func Test_Execute_resolves_runner_within_new_scope_for_each_execution(t *testing.T) {
+
+	// Arrange
+	executions := make([]serveExecution, 0)
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterInstance(registry, &executions)
+	_ = registration.RegisterTransient(registry, newServeCommand)
+	_ = registration.RegisterScoped(registry, newConnection, newServeRunner)
+	_ = cli.RegisterFlagOptions[serveOptions](registry, features.JSONSource([]byte(`{"host": "example.com", "port": 8000}`)))
+
+	resolver := resolving.NewResolver(registry)
+	execute := func(args ...string) error {
+		root, _ := cli.NewRootCommand(resolver, &cobra.Command{Use: "app"})
+		root.SetArgs(args)
+		return cli.Execute(t.Context(), resolver, root)
+	}
+
+	// Act
+	firstErr := execute("serve", "--port", "9090", "--tags", "a,b", "api")
+	secondErr := execute("serve")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Len(t, executions, 2)
+
+	assert.Equal(t, serveOptions{Host: "example.com", Port: 9090, Tags: []string{"a", "b"}}, executions[0].options)
+	assert.Equal(t, []string{"api"}, executions[0].args)
+	assert.True(t, executions[0].connection.closed)
+
+	assert.Equal(t, serveOptions{Host: "example.com", Port: 8000}, executions[1].options)
+	assert.NotSame(t, executions[0].connection, executions[1].connection)
+}
+
+func Test_RegisterFlagOptions_returns_error_for_unsupported_options_type(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+
+	// Act
+	err := cli.RegisterFlagOptions[int](registry)
+
+	// Assert
+	assert.ErrorIs(t, err, types.ErrUnsupportedServiceType)
+	assert.False(t, registry.IsRegistered(types.MakeServiceType[features.Options[int]]()))
+}
+
+func Test_RunE_returns_error_if_command_is_not_executed_by_Execute(t *testing.T) {
+
+	// Arrange
+	command := &cobra.Command{Use: "serve", RunE: cli.RunE[*serveRunner]()}
+	command.SetArgs([]string{})
+	command.SetOut(io.Discard)
+	command.SetErr(io.Discard)
+
+	// Act
+	err := command.ExecuteContext(t.Context())
+
+	// Assert
+	assert.ErrorIs(t, err, cli.ErrNoCommandScope)
+}
+
+func Test_RunE_returns_error_if_runner_cannot_be_resolved(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterTransient(registry, newServeCommand)
+
+	resolver := resolving.NewResolver(registry)
+	root, _ := cli.NewRootCommand(resolver, &cobra.Command{Use: "app", SilenceErrors: true, SilenceUsage: true})
+	root.SetArgs([]string{"serve"})
+
+	// Act
+	err := cli.Execute(t.Context(), resolver, root)
+
+	// Assert
+	assert.ErrorIs(t, err, types.ErrServiceTypeNotRegistered)
+}
+
+type serveOptions struct {
+	Host string   `json:"host"`
+	Port int      `json:"port" flag:"port"`
+	Tags []string `flag:"tags"`
+}
+
+type serveExecution struct {
+	options    serveOptions
+	args       []string
+	connection *connection
+}
+
+type connection struct {
+	closed bool
+}
+
+func newConnection() *connection {
+	return &connection{}
+}
+
+func (c *connection) Close() error {
+	c.closed = true
+	return nil
+}
+
+type serveRunner struct {
+	options    features.Options[serveOptions]
+	connection *connection
+	executions *[]serveExecution
+}
+
+func newServeRunner(options features.Options[serveOptions], connection *connection, executions *[]serveExecution) *serveRunner {
+	return &serveRunner{options: options, connection: connection, executions: executions}
+}
+
+func (r *serveRunner) Run(_ context.Context, args []string) error {
+	*r.executions = append(*r.executions, serveExecution{options: r.options.Value(), args: args, connection: r.connection})
+	return nil
+}
+
+func newServeCommand() cli.Command {
+	return cli.CommandFunc(func() *cobra.Command {
+		command := &cobra.Command{Use: "serve", RunE: cli.RunE[*serveRunner]()}
+		command.Flags().Int("port", 80, "the port to listen on")
+		command.Flags().StringSlice("tags", nil, "the tags of the server")
+		return command
+	})
+}
+
+func newMigrateCommand() cli.Command {
+	return cli.CommandFunc(func() *cobra.Command {
+		return &cobra.Command{Use: "migrate"}
+	})
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateAdapterCommand_Execute(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Handler func(name string) (string, error)\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateAdapterCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type HandlerInvoker interface {\n\tInvoke(name string) (string, error)\n}")
+	assert.Contains(t, actual, "type HandlerInvokerFunc Handler")
+	assert.Contains(t, actual, "func NewHandlerInvoker(fn Handler) HandlerInvoker {")
+}
+
+func Test_GenerateAdapterCommand_Execute_generates_only_marked_func_types(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}\n" + "\n" +
+		"//parsley:adapter\n" +
+		"type Handler func(name string)\n" + "\n" +
+		"type Notify func(message string)\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateAdapterCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "HandlerInvoker")
+	assert.NotContains(t, actual, "NotifyInvoker")
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateConstructorCommand_Execute_generates_activator_function(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type greeter struct {\n" +
+		"	writer Writer\n" +
+		"	clock  Clock `parsley:\"optional\"`\n" +
+		"	count  int   `parsley:\"-\"`\n" +
+		"}\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	sut := commands.NewGenerateConstructorCommand(reflection.AstFromSource(source), outputWriterFactory)
+	sut.SetArgs([]string{"--type", "greeter"})
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "func NewGreeter(ctx context.Context, resolver types.Resolver, writer Writer) (*greeter, error) {")
+	assert.Contains(t, actual, "clock, err := resolving.ResolveRequiredService[Clock](ctx, resolver)")
+	assert.Contains(t, actual, "if err != nil && !errors.Is(err, types.ErrServiceTypeNotRegistered) {")
+	assert.NotContains(t, actual, "count")
+}
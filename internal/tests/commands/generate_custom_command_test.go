@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateCustomCommand_Execute_renders_user_template(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string) string" + "\n" +
+		"}")
+
+	templateLoader := func(name string) (string, error) {
+		if name != "names.gotmpl" {
+			return "", fmt.Errorf("template not found: %s", name)
+		}
+		return "package {{ .PackageName }}\n" +
+			"{{ range .Interfaces }}\n" +
+			"var {{ .Name | asPrivate }}Signatures = []string{ {{ range .Methods }}\"{{ Signature . }}\",{{ end }} }\n" +
+			"{{ end }}", nil
+	}
+
+	var actualKind string
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		actualKind = kind
+		return buffer, nil
+	}
+
+	sut := commands.NewGenerateCustomCommand(reflection.AstFromSource(source), templateLoader, outputWriterFactory)
+	sut.SetArgs([]string{"--template", "names.gotmpl", "--kind", "names"})
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "names", actualKind)
+	assert.Contains(t, actual, "var greeterSignatures = []string{\"SayHello(name string) (string)\"}")
+}
+
+func Test_GenerateCustomCommand_Execute_does_not_write_output_if_template_is_invalid(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n")
+
+	templateLoader := func(name string) (string, error) {
+		return "package {{ .PackageName }}\n\n{{ .Unknown }}\n", nil
+	}
+
+	outputWritten := false
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		outputWritten = true
+		return mocks.NewMemoryFile(), nil
+	}
+
+	sut := commands.NewGenerateCustomCommand(reflection.AstFromSource(source), templateLoader, outputWriterFactory)
+	sut.SetArgs([]string{"--template", "invalid.gotmpl", "--kind", "invalid"})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotExecuteTemplate)
+	assert.False(t, outputWritten)
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateDecoratorCommand_Execute(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"import \"context\"\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(ctx context.Context, name string) (string, error)" + "\n" +
+		"	Names(prefix string, names ...string)" + "\n" +
+		"}")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateDecoratorCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type GreeterDecoratorBase struct {\n\tGreeter\n}")
+	assert.Contains(t, actual, "func NewGreeterDecoratorBase(inner Greeter) Greeter {")
+	assert.NotContains(t, actual, "func (d *GreeterDecoratorBase)")
+	assert.NotContains(t, actual, "import")
+}
+
+func Test_GenerateDecoratorCommand_Execute_skips_nodecorator_interfaces(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}\n" + "\n" +
+		"//parsley:nodecorator\n" +
+		"type Farewell interface {\n" +
+		"	SayGoodbye(name string)" + "\n" +
+		"}")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateDecoratorCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "GreeterDecoratorBase")
+	assert.NotContains(t, actual, "FarewellDecoratorBase")
+}
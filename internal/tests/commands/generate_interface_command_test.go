@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateInterfaceCommand_Execute_generates_interface_and_assertion(t *testing.T) {
+
+	// Arrange
+	service := []byte("package main\n" + "\n" +
+		"type greeter struct{}\n" + "\n" +
+		"func (g *greeter) SayHello(name string) string { return name }\n")
+
+	more := []byte("package main\n" + "\n" +
+		"func (g greeter) Close() error { return nil }\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	sut := commands.NewGenerateInterfaceCommand(reflection.AstFromSource(service), reflection.AstPackageFromSources(service, more), outputWriterFactory)
+	sut.SetArgs([]string{"--type", "greeter", "--exclude", "Close"})
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type Greeter interface {")
+	assert.Contains(t, actual, "SayHello(name string) string")
+	assert.NotContains(t, actual, "Close()")
+	assert.Contains(t, actual, "var _ Greeter = (*greeter)(nil)")
+}
@@ -1,12 +1,19 @@
 package commands
 
 import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
 	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/matzefriedrich/parsley/internal/reflection"
 	"github.com/matzefriedrich/parsley/internal/tests/mocks"
 	"github.com/stretchr/testify/assert"
-	"io"
-	"testing"
 )
 
 func Test_GenerateMocksCommand_Execute(t *testing.T) {
@@ -35,3 +42,75 @@ func Test_GenerateMocksCommand_Execute(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, actual)
 }
+
+func Test_GenerateMocksCommand_Execute_generates_mocks_for_func_types(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Notify func(message string, codes ...int) error\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateMocksCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type notifyMock struct")
+	assert.Contains(t, actual, "InvokeFunc Notify")
+	assert.Contains(t, actual, "m.TraceMethodCall(Function_Notify, message, codes)")
+	assert.Contains(t, actual, "return m.InvokeFunc(message, codes...)")
+	assert.Contains(t, actual, "var _ Notify = (*notifyMock)(nil).Invoke")
+}
+
+func Test_GenerateMocksCommand_watch_regenerates_mocks_of_changed_files_and_continues_after_errors(t *testing.T) {
+
+	// Arrange
+	dir := t.TempDir()
+	sourceFilePath := filepath.Join(dir, "greeter.go")
+	const header = "package main\n" + "\n" + "//go:generate parsley-cli generate mocks\n" + "\n"
+	assert.NoError(t, os.WriteFile(sourceFilePath, []byte(header+"type Greeter interface {\n	SayHello(name string)\n}\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.go"), []byte("package main\n\ntype Clock interface {\n	Now() int\n}\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sut := commands.NewGenerateMocksCommand(reflection.AstFromSource([]byte("package main\n")), generator.FileOutputWriter())
+	sut.SetArgs([]string{"--watch", dir})
+	sut.SetOut(io.Discard)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sut.ExecuteContext(ctx)
+	}()
+
+	mocksFilePath := generator.GeneratedFilePath("mocks", sourceFilePath)
+	generatedContains := func(text string) func() bool {
+		return func() bool {
+			content, err := os.ReadFile(mocksFilePath)
+			return err == nil && strings.Contains(string(content), text)
+		}
+	}
+
+	// Act
+	assert.Eventually(t, generatedContains("GreeterMock"), 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, os.WriteFile(sourceFilePath, []byte(header+"type Greeter interface {\n"), 0644))
+	time.Sleep(time.Second)
+	assert.NoError(t, os.WriteFile(sourceFilePath, []byte(header+"type Greeter interface {\n	SayHello(name string)\n}\n\ntype Clock interface {\n	Now() int\n}\n"), 0644))
+
+	// Assert
+	assert.Eventually(t, generatedContains("ClockMock"), 5*time.Second, 10*time.Millisecond)
+	_, err := os.Stat(generator.GeneratedFilePath("mocks", filepath.Join(dir, "other.go")))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateModuleCommand_Execute_registers_annotated_constructors(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"import \"io\"\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}\n" + "\n" +
+		"type greeter struct{}\n" + "\n" +
+		"func (g *greeter) SayHello(name string) {}\n" + "\n" +
+		"//parsley:register singleton\n" +
+		"func NewGreeter() Greeter { return &greeter{} }\n" + "\n" +
+		"//parsley:register scoped as=Greeter\n" +
+		"func newConcreteGreeter(w io.Writer) (*greeter, error) { return &greeter{}, nil }\n" + "\n" +
+		"//parsley:register transient as=Greeter name=polite\n" +
+		"func NewPoliteGreeter() Greeter { return &greeter{} }\n" + "\n" +
+		"func NewUnannotated() Greeter { return &greeter{} }\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateModuleCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "func RegisterMainModule(registry types.ServiceRegistry) error {")
+	assert.Contains(t, actual, "registration.RegisterSingleton(registry, NewGreeter)")
+	assert.Contains(t, actual, "registration.RegisterScoped(registry, func(w io.Writer) (Greeter, error) {")
+	assert.Contains(t, actual, "features.RegisterNamed[Greeter](registry,")
+	assert.Contains(t, actual, "registration.NamedServiceRegistration(\"polite\", NewPoliteGreeter, types.LifetimeTransient)")
+	assert.Contains(t, actual, "\"io\"")
+	assert.NotContains(t, actual, "NewUnannotated")
+}
+
+func Test_GenerateModuleCommand_Execute_does_not_write_output_for_invalid_activator(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"//parsley:register singleton\n" +
+		"func NewName() string { return \"\" }\n")
+
+	outputRequested := false
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		outputRequested = true
+		return mocks.NewMemoryFile(), nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateModuleCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	_ = sut.Execute()
+
+	// Assert
+	assert.False(t, outputRequested)
+}
@@ -1,12 +1,13 @@
 package commands
 
 import (
+	"io"
+	"testing"
+
 	"github.com/matzefriedrich/parsley/internal/commands"
 	"github.com/matzefriedrich/parsley/internal/reflection"
 	"github.com/matzefriedrich/parsley/internal/tests/mocks"
 	"github.com/stretchr/testify/assert"
-	"io"
-	"testing"
 )
 
 func Test_GenerateProxyCommand_Execute(t *testing.T) {
@@ -34,3 +35,97 @@ func Test_GenerateProxyCommand_Execute(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, actual)
 }
+
+func Test_GenerateProxyCommand_Execute_generates_only_marked_interfaces(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"//parsley:proxy exclude=Close\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"	Close() error" + "\n" +
+		"}\n" + "\n" +
+		"type Farewell interface {\n" +
+		"	SayGoodbye(name string)" + "\n" +
+		"}")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateProxyCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type greeterProxyImpl struct")
+	assert.NotContains(t, actual, "farewellProxyImpl")
+	assert.Contains(t, actual, "const methodName = \"SayHello\"")
+	assert.NotContains(t, actual, "const methodName = \"Close\"")
+	assert.Contains(t, actual, "return p.target.Close()")
+}
+
+func Test_GenerateProxyCommand_Execute_skips_noproxy_interfaces(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}\n" + "\n" +
+		"//parsley:noproxy\n" +
+		"type Farewell interface {\n" +
+		"	SayGoodbye(name string)" + "\n" +
+		"}")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateProxyCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type greeterProxyImpl struct")
+	assert.NotContains(t, actual, "farewellProxyImpl")
+}
+
+func Test_GenerateProxyCommand_Execute_generates_proxies_for_func_types(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"import \"context\"\n" + "\n" +
+		"type Handler func(ctx context.Context, name string) (string, error)\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	fileAccessor := reflection.AstFromSource(source)
+	sut := commands.NewGenerateProxyCommand(fileAccessor, outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type HandlerProxy Handler")
+	assert.Contains(t, actual, "func NewHandlerProxy(target Handler, interceptors []features.MethodInterceptor) HandlerProxy {")
+	assert.Contains(t, actual, "const methodName = \"Handler\"")
+	assert.Contains(t, actual, "result0, result1 := invoker.Handler(ctx, name)")
+}
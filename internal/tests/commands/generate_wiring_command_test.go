@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateWiringCommand_Execute_generates_container(t *testing.T) {
+
+	// Arrange
+	services := []byte("package main\n" + "\n" +
+		"import \"github.com/matzefriedrich/parsley/pkg/types\"\n" +
+		"import \"github.com/matzefriedrich/parsley/pkg/registration\"\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}\n" + "\n" +
+		"func NewGreeter() Greeter { return nil }\n" + "\n" +
+		"func configureServices(registry types.ServiceRegistry) error {\n" +
+		"	return registration.RegisterScoped(registry, NewGreeter)\n" +
+		"}\n")
+
+	application := []byte("package main\n" + "\n" +
+		"import \"github.com/matzefriedrich/parsley/pkg/bootstrap\"\n" + "\n" +
+		"func NewApp(greeter Greeter) bootstrap.Application { return nil }\n")
+
+	buffer := mocks.NewMemoryFile()
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return buffer, nil
+	}
+
+	sut := commands.NewGenerateWiringCommand(reflection.AstFromSource(application), reflection.AstPackageFromSources(services, application), outputWriterFactory)
+
+	// Act
+	err := sut.Execute()
+
+	actual := buffer.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "type WiredContainer struct")
+	assert.Contains(t, actual, "wiredContainerNewGreeterScopeKey = resolving.ScopedServiceKey[Greeter](NewGreeter)")
+	assert.Contains(t, actual, "resolving.ResolveScoped(ctx, wiredContainerNewGreeterScopeKey,")
+	assert.Contains(t, actual, "return NewApp(p0), nil")
+	assert.Contains(t, actual, "types.NewResolverError(types.ErrorResolverOptionsNotSupported")
+}
+
+func Test_GenerateWiringCommand_Execute_generates_compilable_container_for_package_with_generated_module(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("compiles the generated code")
+	}
+
+	// Arrange
+	repositoryRoot, _ := filepath.Abs(filepath.Join("..", "..", ".."))
+	projectFolder := t.TempDir()
+	goMod := fmt.Sprintf("module example.com/app\n\ngo 1.26\n\nrequire github.com/matzefriedrich/parsley v1.6.0\n\nreplace github.com/matzefriedrich/parsley => %s\n", repositoryRoot)
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "go.mod"), []byte(goMod), 0644))
+	goSum, _ := os.ReadFile(filepath.Join(repositoryRoot, "go.sum"))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "go.sum"), goSum, 0644))
+
+	services := "package main\n\n" +
+		"import \"io\"\n\n" +
+		"type Greeter interface {\n\tSayHello(name string) string\n}\n\n" +
+		"type greeter struct{}\n\n" +
+		"func (g *greeter) SayHello(name string) string { return \"Hello \" + name }\n\n" +
+		"//parsley:register singleton\n" +
+		"func NewGreeter() Greeter { return &greeter{} }\n\n" +
+		"//parsley:register scoped as=io.Writer\n" +
+		"func newBuffer() (*buffer, error) { return &buffer{}, nil }\n\n" +
+		"type buffer struct{}\n\n" +
+		"func (b *buffer) Write(p []byte) (int, error) { return len(p), nil }\n\n" +
+		"type App struct {\n\tgreeter Greeter\n\tw io.Writer\n}\n\n" +
+		"func NewApp(greeter Greeter, w io.Writer) *App { return &App{greeter: greeter, w: w} }\n\n" +
+		"func main() {}\n"
+	servicesFile := filepath.Join(projectFolder, "services.go")
+	assert.NoError(t, os.WriteFile(servicesFile, []byte(services), 0644))
+
+	generateModule := commands.NewGenerateModuleCommand(reflection.AstFromFile(servicesFile), generator.FileOutputWriter())
+	generateModule.SetArgs([]string{})
+	assert.NoError(t, generateModule.Execute())
+
+	sut := commands.NewGenerateWiringCommand(reflection.AstFromFile(servicesFile), reflection.AstPackageFromDirectory(projectFolder), generator.FileOutputWriter())
+	sut.SetArgs([]string{"--root", "NewApp"})
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(projectFolder, "services.module.g.go"))
+	assert.FileExists(t, filepath.Join(projectFolder, "services.wiring.g.go"))
+
+	build := exec.Command("go", "build", "./...")
+	build.Dir = projectFolder
+	build.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	output, buildErr := build.CombinedOutput()
+	assert.NoError(t, buildErr, string(output))
+}
@@ -1,19 +1,188 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
 	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/matzefriedrich/parsley/internal/tests/mocks"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func Test_NewGenerateGroupCommand_Execute(t *testing.T) {
 
 	// Arrange
-	sut := commands.NewGenerateGroupCommand()
+	sut := commands.NewGenerateGroupCommand(commands.LoadProjectFromDisk)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func Test_GenerateGroupCommand_check_returns_error_if_generated_code_is_outdated(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}")
+
+	outputWritten := false
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		outputWritten = true
+		return mocks.NewMemoryFile(), nil
+	}
+
+	sut := commands.NewGenerateGroupCommand(commands.LoadProjectFromDisk)
+	sut.AddCommand(commands.NewGenerateMocksCommand(reflection.AstFromSource(source), outputWriterFactory))
+	sut.SetArgs([]string{"mocks", "--check"})
+	sut.SetOut(io.Discard)
+	sut.SetErr(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrGeneratedCodeIsOutdated)
+	assert.False(t, outputWritten)
+}
+
+func Test_GenerateMocksCommand_skips_generation_if_source_hash_is_unchanged(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}")
+
+	buffer := mocks.NewMemoryFile()
+	writes := 0
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		writes++
+		return buffer, nil
+	}
+
+	options := &commands.GenerateOptions{
+		OutputReaderFactory: func(kind string, source *reflection.AstFileSource) ([]byte, error) {
+			if writes == 0 {
+				return nil, os.ErrNotExist
+			}
+			return []byte(buffer.String()), nil
+		},
+	}
+	ctx := commands.WithGenerateOptions(context.Background(), options)
+
+	sut := commands.NewGenerateMocksCommand(reflection.AstFromSource(source), outputWriterFactory)
+
+	// Act
+	_ = sut.ExecuteContext(ctx)
+	_ = sut.ExecuteContext(ctx)
+
+	// Assert
+	assert.Equal(t, 1, writes)
+	assert.Contains(t, buffer.String(), "// Source hash: ")
+}
+
+func Test_RootCommand_generate_output_json_reports_generated_files_and_skipped_types(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}\n" + "\n" +
+		"//parsley:ignore\n" +
+		"type Clock interface {\n" +
+		"	Now() int" + "\n" +
+		"}")
+
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return mocks.NewMemoryFile(), nil
+	}
+
+	output := &bytes.Buffer{}
+	generateCommand := commands.NewGenerateGroupCommand(commands.LoadProjectFromDisk)
+	generateCommand.AddCommand(commands.NewGenerateMocksCommand(reflection.AstFromSource(source), outputWriterFactory))
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(generateCommand)
+	sut.SetArgs([]string{"generate", "mocks", "--output", "json"})
+	sut.SetOut(output)
 
 	// Act
 	err := sut.Execute()
 
 	// Assert
 	assert.NoError(t, err)
+
+	actual := commands.CommandReport{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &actual))
+	assert.True(t, actual.Success)
+	assert.Equal(t, "mocks", actual.Command)
+	assert.Equal(t, []commands.GeneratedFileReport{{Path: ".mocks.g.go", Kind: "mocks", Status: generator.Generated}}, actual.Files)
+	assert.Equal(t, []commands.SkippedTypeReport{{Name: "Clock", Reason: "marked with //parsley:ignore"}}, actual.Skipped)
+	assert.Empty(t, actual.Errors)
+}
+
+func Test_RootCommand_generate_output_json_reports_errors_and_returns_error(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n" + "\n" +
+		"type Greeter interface {\n" +
+		"	SayHello(name string)" + "\n" +
+		"}")
+
+	writerErr := errors.New("disk full")
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return nil, writerErr
+	}
+
+	output := &bytes.Buffer{}
+	generateCommand := commands.NewGenerateGroupCommand(commands.LoadProjectFromDisk)
+	generateCommand.AddCommand(commands.NewGenerateMocksCommand(reflection.AstFromSource(source), outputWriterFactory))
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(generateCommand)
+	sut.SetArgs([]string{"generate", "mocks", "--output", "json"})
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorIs(t, err, writerErr)
+
+	actual := commands.CommandReport{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &actual))
+	assert.False(t, actual.Success)
+	assert.Empty(t, actual.Files)
+	assert.Equal(t, []commands.ErrorReport{{Message: "disk full"}}, actual.Errors)
+}
+
+func Test_RootCommand_returns_error_for_unsupported_output_format(t *testing.T) {
+
+	// Arrange
+	outputWriterFactory := func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+		return mocks.NewMemoryFile(), nil
+	}
+
+	generateCommand := commands.NewGenerateGroupCommand(commands.LoadProjectFromDisk)
+	generateCommand.AddCommand(commands.NewGenerateMocksCommand(reflection.AstFromSource([]byte("package main\n")), outputWriterFactory))
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(generateCommand)
+	sut.SetArgs([]string{"generate", "mocks", "--output", "yaml"})
+	sut.SetOut(io.Discard)
+	sut.SetErr(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid output format \"yaml\"")
 }
@@ -1,37 +1,33 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
 	"github.com/matzefriedrich/parsley/internal/commands"
 	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/matzefriedrich/parsley/internal/utils"
 	"github.com/stretchr/testify/assert"
-	"io"
-	"testing"
+	"golang.org/x/mod/modfile"
 )
 
 func Test_InitCommand_Execute_adds_project_reference_and_scaffolds_files(t *testing.T) {
 
 	// Arrange
-	expectedProjectFiles := []string{"application.go", "main.go", "greeter.go"}
+	expectedProjectFiles := []string{"application.go", "main.go", "greeter.go", "greeter.mocks.g.go", "greet_command.go", "greet_command_test.go"}
 	files := make(map[string]mocks.MemoryFile)
 
-	writerFactory := func(projectFolder string) (generator.ScaffoldingFileWriterFunc, error) {
-		return func(targetFilename string) (io.WriteCloser, error) {
-			f, found := files[targetFilename]
-			if found {
-				return f, nil
-			}
-			f = mocks.NewMemoryFile()
-			files[targetFilename] = f
-			return f, nil
-		}, nil
-	}
-
 	projectInstance := &memoryGoProject{
 		packages: make(map[string]string),
 	}
 
-	sut := commands.NewInitCommand(writerFactory, func(projectFolderPath string) (generator.GoProject, error) {
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
 		return projectInstance, nil
 	})
 
@@ -52,6 +48,206 @@ func Test_InitCommand_Execute_adds_project_reference_and_scaffolds_files(t *test
 	}
 }
 
+func Test_InitCommand_Execute_adds_valid_requirement_to_go_mod(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+	projectFolderPath := t.TempDir()
+	t.Chdir(projectFolderPath)
+	modFilePath := filepath.Join(projectFolderPath, "go.mod")
+	_ = os.WriteFile(modFilePath, []byte("module example.com/app\n\ngo 1.23\n"), 0644)
+
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), generator.OpenProject)
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+
+	data, _ := os.ReadFile(modFilePath)
+	modFile, parseErr := modfile.Parse(modFilePath, data, nil)
+	assert.NoError(t, parseErr)
+	assert.Len(t, modFile.Require, 1)
+	assert.Equal(t, "github.com/matzefriedrich/parsley", modFile.Require[0].Mod.Path)
+	assert.Regexp(t, `^v\d+\.\d+\.\d+$`, modFile.Require[0].Mod.Version)
+}
+
+func Test_InitCommand_Execute_scaffolds_files_of_selected_template(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return &memoryGoProject{packages: make(map[string]string)}, nil
+	})
+	sut.SetArgs([]string{"--template", "http-server"})
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, files, "hello_handler.go")
+	assert.Contains(t, files["application.go"].String(), "resolving.NewScopedContext(r.Context())")
+}
+
+func Test_InitCommand_Execute_does_not_overwrite_existing_files(t *testing.T) {
+
+	// Arrange
+	existing := mocks.NewMemoryFile()
+	_, _ = existing.Write([]byte("package main\n"))
+	files := map[string]mocks.MemoryFile{"main.go": existing}
+
+	projectInstance := &memoryGoProject{
+		packages: make(map[string]string),
+	}
+
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return projectInstance, nil
+	})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrProjectFilesAlreadyExist)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "package main\n", files["main.go"].String())
+	assert.Empty(t, projectInstance.packages)
+}
+
+func Test_InitCommand_Execute_overwrites_existing_files_if_forced(t *testing.T) {
+
+	// Arrange
+	existing := mocks.NewMemoryFile()
+	_, _ = existing.Write([]byte("package main\n"))
+	files := map[string]mocks.MemoryFile{"main.go": existing}
+
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return &memoryGoProject{packages: make(map[string]string)}, nil
+	})
+	sut.SetArgs([]string{"--force"})
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, files["main.go"].String(), "func main()")
+}
+
+func Test_InitCommand_Execute_dry_run_does_not_change_the_project(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+
+	projectInstance := &memoryGoProject{
+		packages: make(map[string]string),
+	}
+
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return projectInstance, nil
+	})
+	sut.SetArgs([]string{"--dry-run", "--template", "worker"})
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	assert.Empty(t, projectInstance.packages)
+}
+
+func Test_InitCommand_Execute_warns_if_required_library_version_is_not_compatible(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+	t.Chdir(t.TempDir())
+
+	previousVersion := utils.VersionString
+	utils.VersionString = "1.4.2"
+	t.Cleanup(func() { utils.VersionString = previousVersion })
+
+	projectInstance := &memoryGoProject{
+		packages: map[string]string{"github.com/matzefriedrich/parsley": "v1.6.0"},
+	}
+
+	output := &bytes.Buffer{}
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return projectInstance, nil
+	})
+	sut.SetArgs([]string{"--dry-run"})
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "warning: Parsley CLI v1.4.2 is not compatible with the Parsley library v1.6.0 required by go.mod; "+
+		"breaking changes between v1.4.2 and v1.6.0: v1.5.0 (lazy-value-context), v1.5.0 (register-list-without-context), v1.5.2 (register-named-without-context)\n")
+}
+
+func Test_InitCommand_Execute_returns_error_for_unknown_template(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return &memoryGoProject{packages: make(map[string]string)}, nil
+	})
+	sut.SetArgs([]string{"--template", "desktop"})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrUnknownProjectTemplate)
+	assert.Empty(t, files)
+}
+
+func Test_InitCommand_Execute_returns_error_if_project_cannot_be_loaded(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+
+	projectErr := errors.New("go.mod not found")
+	sut := commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return nil, projectErr
+	})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorIs(t, err, projectErr)
+}
+
+func memoryWriterFactory(files map[string]mocks.MemoryFile) commands.ScaffoldingFileWriterFactoryFunc {
+	return func(projectFolder string) (generator.ScaffoldingFileWriterFunc, error) {
+		return func(targetFilename string) (io.WriteCloser, error) {
+			f := mocks.NewMemoryFile()
+			files[targetFilename] = f
+			return f, nil
+		}, nil
+	}
+}
+
+func memoryFileExists(files map[string]mocks.MemoryFile) commands.ScaffoldingFileExistsFactoryFunc {
+	return func(projectFolder string) generator.ScaffoldingFileExistsFunc {
+		return func(targetFilename string) (bool, error) {
+			_, found := files[targetFilename]
+			return found, nil
+		}
+	}
+}
+
 type memoryGoProject struct {
 	packages map[string]string
 }
@@ -61,4 +257,42 @@ func (m *memoryGoProject) AddDependency(packageName string, version string) erro
 	return nil
 }
 
+func (m *memoryGoProject) DependencyVersion(packageName string) (string, bool, error) {
+	version, found := m.packages[packageName]
+	return version, found, nil
+}
+
+func (m *memoryGoProject) SetDependencyVersion(packageName string, version string) error {
+	m.packages[packageName] = version
+	return nil
+}
+
 var _ generator.GoProject = (*memoryGoProject)(nil)
+
+func Test_RootCommand_init_output_json_reports_scaffolded_files_as_messages(t *testing.T) {
+
+	// Arrange
+	files := make(map[string]mocks.MemoryFile)
+	t.Chdir(t.TempDir())
+
+	output := &bytes.Buffer{}
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(commands.NewInitCommand(memoryWriterFactory(files), memoryFileExists(files), func(projectFolderPath string) (generator.GoProject, error) {
+		return &memoryGoProject{packages: make(map[string]string)}, nil
+	}))
+	sut.SetArgs([]string{"init", "--dry-run", "--output", "json"})
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+
+	report := commands.CommandReport{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, "init", report.Command)
+	assert.True(t, report.Success)
+	assert.Contains(t, report.Messages, "would create main.go")
+	assert.Empty(t, files)
+}
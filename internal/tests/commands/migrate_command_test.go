@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+const migrateTestSource = "package main\n" + "\n" +
+	"import \"github.com/matzefriedrich/parsley/pkg/features\"\n" + "\n" +
+	"func configure(ctx context.Context, registry types.ServiceRegistry) error {\n" +
+	"	return features.RegisterList[Greeter](ctx, registry)\n" +
+	"}\n"
+
+func Test_MigrateCommand_Execute_rewrites_files_and_updates_required_version(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	sourceFilePath := filepath.Join(projectFolder, "main.go")
+	assert.NoError(t, os.WriteFile(sourceFilePath, []byte(migrateTestSource), 0644))
+	t.Chdir(projectFolder)
+
+	projectInstance := &memoryGoProject{
+		packages: map[string]string{"github.com/matzefriedrich/parsley": "v1.4.2"},
+	}
+
+	sut := commands.NewMigrateCommand(migration.NewRuleRegistry(migration.RegisterListWithoutContextRule()), func(projectFolderPath string) (generator.GoProject, error) {
+		return projectInstance, nil
+	})
+	sut.SetArgs([]string{"--to", "v1.6"})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	actual, _ := os.ReadFile(sourceFilePath)
+	assert.Contains(t, string(actual), "features.RegisterList[Greeter](registry)")
+	assert.Equal(t, "v1.6.0", projectInstance.packages["github.com/matzefriedrich/parsley"])
+}
+
+func Test_MigrateCommand_Execute_dry_run_does_not_change_the_module(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	sourceFilePath := filepath.Join(projectFolder, "main.go")
+	assert.NoError(t, os.WriteFile(sourceFilePath, []byte(migrateTestSource), 0644))
+	t.Chdir(projectFolder)
+
+	projectInstance := &memoryGoProject{
+		packages: map[string]string{"github.com/matzefriedrich/parsley": "v1.4.2"},
+	}
+
+	sut := commands.NewMigrateCommand(migration.NewRuleRegistry(migration.RegisterListWithoutContextRule()), func(projectFolderPath string) (generator.GoProject, error) {
+		return projectInstance, nil
+	})
+	sut.SetArgs([]string{"--from", "v1.4", "--to", "v1.6", "--dry-run"})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	actual, _ := os.ReadFile(sourceFilePath)
+	assert.Equal(t, migrateTestSource, string(actual))
+	assert.Equal(t, "v1.4.2", projectInstance.packages["github.com/matzefriedrich/parsley"])
+}
+
+func Test_MigrateCommand_Execute_returns_error_if_to_version_is_missing(t *testing.T) {
+
+	// Arrange
+	sut := commands.NewMigrateCommand(migration.NewRuleRegistry(), func(projectFolderPath string) (generator.GoProject, error) {
+		return &memoryGoProject{packages: make(map[string]string)}, nil
+	})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorContains(t, err, "the --to flag is required")
+}
+
+func Test_RootCommand_migrate_output_json_reports_diffs_as_messages(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "main.go"), []byte(migrateTestSource), 0644))
+	t.Chdir(projectFolder)
+
+	projectInstance := &memoryGoProject{
+		packages: map[string]string{"github.com/matzefriedrich/parsley": "v1.4.2"},
+	}
+
+	output := &bytes.Buffer{}
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(commands.NewMigrateCommand(migration.NewRuleRegistry(migration.RegisterListWithoutContextRule()), func(projectFolderPath string) (generator.GoProject, error) {
+		return projectInstance, nil
+	}))
+	sut.SetArgs([]string{"migrate", "--to", "v1.6", "--dry-run", "--output", "json"})
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+
+	report := commands.CommandReport{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, "migrate", report.Command)
+	assert.True(t, report.Success)
+	assert.Contains(t, strings.Join(report.Messages, "\n"), "--- a/main.go")
+	assert.Contains(t, report.Messages, "go.mod: require github.com/matzefriedrich/parsley v1.4.2 => v1.6.0")
+}
@@ -2,11 +2,18 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
 	"github.com/matzefriedrich/parsley/internal/commands"
 	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/matzefriedrich/parsley/internal/utils"
 	"github.com/stretchr/testify/assert"
-	"net/http"
-	"testing"
 )
 
 func Test_NewVersionCommand_Execute_check_for_update(t *testing.T) {
@@ -25,7 +32,7 @@ func Test_NewVersionCommand_Execute_check_for_update(t *testing.T) {
 		}, nil
 	}
 
-	sut := commands.NewVersionCommand(httpClientMock)
+	sut := commands.NewVersionCommand(httpClientMock, commands.LoadProjectFromDisk)
 	sut.SetArgs([]string{"--check-update"})
 
 	// Act
@@ -34,3 +41,120 @@ func Test_NewVersionCommand_Execute_check_for_update(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 }
+
+func Test_NewVersionCommand_Execute_warns_about_incompatible_versions_offline(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	goMod := "module example.com/app\n\ngo 1.24\n\nrequire github.com/matzefriedrich/parsley v1.6.0\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "go.mod"), []byte(goMod), 0644))
+	generatedCode := "// Code generated by parsley-cli; DO NOT EDIT.\n// Parsley CLI version: v1.4.2\n\npackage main\n"
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectFolder, "services"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "services", "greeter.mocks.g.go"), []byte(generatedCode), 0644))
+	t.Chdir(filepath.Join(projectFolder, "services"))
+
+	previousVersion := utils.VersionString
+	utils.VersionString = "1.6.1"
+	t.Cleanup(func() { utils.VersionString = previousVersion })
+
+	httpClientMock := mocks.NewHttpClientMock()
+	httpClientMock.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("offline")
+	}
+
+	output := &bytes.Buffer{}
+	sut := commands.NewVersionCommand(httpClientMock, commands.LoadProjectFromDisk)
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(output.String(), "warning: "))
+	assert.Contains(t, output.String(), "greeter.mocks.g.go was generated by Parsley CLI v1.4.2, which is not compatible with the Parsley library v1.6.0; "+
+		"breaking changes between v1.4.2 and v1.6.0: v1.5.0 (lazy-value-context), v1.5.0 (register-list-without-context), v1.5.2 (register-named-without-context); regenerate the file\n")
+}
+
+func Test_NewVersionCommand_Execute_does_not_warn_about_files_generated_by_the_running_cli(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	goMod := "module example.com/app\n\ngo 1.24\n\nrequire github.com/matzefriedrich/parsley v1.6.0\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "go.mod"), []byte(goMod), 0644))
+	generatedCode := "// Code generated by parsley-cli; DO NOT EDIT.\n// Parsley CLI version: v1.4.2\n\npackage main\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "greeter.mocks.g.go"), []byte(generatedCode), 0644))
+	t.Chdir(projectFolder)
+
+	previousVersion := utils.VersionString
+	utils.VersionString = "1.4.2"
+	t.Cleanup(func() { utils.VersionString = previousVersion })
+
+	output := &bytes.Buffer{}
+	sut := commands.NewVersionCommand(mocks.NewHttpClientMock(), commands.LoadProjectFromDisk)
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(output.String(), "warning: "))
+	assert.Contains(t, output.String(), "warning: Parsley CLI v1.4.2 is not compatible with the Parsley library v1.6.0 required by go.mod")
+}
+
+func Test_NewVersionCommand_Execute_does_not_check_cli_compatibility_of_development_build(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	goMod := "module example.com/app\n\ngo 1.24\n\nrequire github.com/matzefriedrich/parsley v1.6.0\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "go.mod"), []byte(goMod), 0644))
+	t.Chdir(projectFolder)
+
+	previousVersion := utils.VersionString
+	utils.VersionString = ""
+	t.Cleanup(func() { utils.VersionString = previousVersion })
+
+	output := &bytes.Buffer{}
+	sut := commands.NewVersionCommand(mocks.NewHttpClientMock(), commands.LoadProjectFromDisk)
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotContains(t, output.String(), "warning:")
+}
+
+func Test_RootCommand_version_output_json_reports_versions_as_messages(t *testing.T) {
+
+	// Arrange
+	projectFolder := t.TempDir()
+	goMod := "module example.com/app\n\ngo 1.24\n\nrequire github.com/matzefriedrich/parsley v1.6.0\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(projectFolder, "go.mod"), []byte(goMod), 0644))
+	t.Chdir(projectFolder)
+
+	previousVersion := utils.VersionString
+	utils.VersionString = "1.4.2"
+	t.Cleanup(func() { utils.VersionString = previousVersion })
+
+	output := &bytes.Buffer{}
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(commands.NewVersionCommand(mocks.NewHttpClientMock(), commands.LoadProjectFromDisk))
+	sut.SetArgs([]string{"version", "--output", "json"})
+	sut.SetOut(output)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+
+	report := commands.CommandReport{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, "version", report.Command)
+	assert.True(t, report.Success)
+	assert.Equal(t, []string{"Parsley CLI v1.4.2", "Parsley library v1.6.0 (go.mod)"}, report.Messages)
+	assert.NotEmpty(t, report.Warnings)
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/commands"
+	"github.com/matzefriedrich/parsley/pkg/analyzers"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VetCommand_Execute_returns_error_if_analyzers_report_problems(t *testing.T) {
+
+	// Arrange
+	sut := commands.NewVetCommand(analyzers.Analyzers()...)
+	sut.SetArgs([]string{"../analyzers/testdata/src/registererror"})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorContains(t, err, "found 4 problems")
+}
+
+func Test_VetCommand_Execute_succeeds_if_no_problems_are_found(t *testing.T) {
+
+	// Arrange
+	sut := commands.NewVetCommand(analyzers.Analyzers()...)
+	sut.SetArgs([]string{"../../../pkg/..."})
+	sut.SetOut(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func Test_RootCommand_vet_output_json_reports_diagnostics_and_returns_error(t *testing.T) {
+
+	// Arrange
+	output := &bytes.Buffer{}
+	sut := commands.NewRootCommand("parsley-cli", "")
+	sut.AddCommand(commands.NewVetCommand(analyzers.Analyzers()...))
+	sut.SetArgs([]string{"vet", "../analyzers/testdata/src/registererror", "--output", "json"})
+	sut.SetOut(output)
+	sut.SetErr(io.Discard)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	assert.ErrorContains(t, err, "found 4 problems")
+
+	report := commands.CommandReport{}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	assert.Equal(t, "vet", report.Command)
+	assert.False(t, report.Success)
+	assert.Len(t, report.Messages, 4)
+	assert.Len(t, report.Errors, 1)
+}
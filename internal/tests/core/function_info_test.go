@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/matzefriedrich/parsley/internal/core"
+	"github.com/matzefriedrich/parsley/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,6 +46,20 @@ func Test_FunctionInfo_ReflectFunctionInfoFrom_function_with_three_return_values
 	assert.ErrorIs(t, err, core.ErrReturnTypeHasToHaveExactlyOnReturnValue)
 }
 
+func Test_FunctionInfo_ReflectFunctionInfoFrom_function_with_unsupported_parameter_type_returns_error(t *testing.T) {
+
+	// Arrange
+	f := func(port int) some {
+		return nil
+	}
+
+	// Act
+	_, err := core.ReflectFunctionInfoFrom(reflect.ValueOf(f))
+
+	// Assert
+	assert.ErrorIs(t, err, types.ErrUnsupportedServiceType)
+}
+
 func Test_FunctionInfo_ReflectFunctionInfoFrom_function_with_two_return_values_including_error_succeeds(t *testing.T) {
 
 	// Arrange
@@ -0,0 +1,74 @@
+package features
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RegisterLogger_injects_logger_tagged_with_consumer_type(t *testing.T) {
+
+	// Arrange
+	output := &bytes.Buffer{}
+	registry := registration.NewServiceRegistry()
+	_ = features.RegisterLogger(registry, slog.New(slog.NewJSONHandler(output, nil)))
+	_ = registration.RegisterTransient(registry, newOrderRepository, newOrderService)
+
+	resolver := resolving.NewResolver(registry)
+	ctx := resolving.NewScopedContext(t.Context())
+
+	// Act
+	service, err := resolving.ResolveRequiredService[*orderService](ctx, resolver)
+	logger, _ := resolving.ResolveRequiredService[*slog.Logger](ctx, resolver)
+
+	// Assert
+	assert.NoError(t, err)
+	service.logger.Info("order placed")
+	service.repository.logger.Info("order saved")
+	logger.Info("done")
+
+	const packagePath = "github.com/matzefriedrich/parsley/internal/tests/features"
+	records := logRecords(t, output)
+	assert.Equal(t, []map[string]any{
+		{"msg": "order placed", features.LoggerServiceKey: "orderService", features.LoggerPackageKey: packagePath},
+		{"msg": "order saved", features.LoggerServiceKey: "orderRepository", features.LoggerPackageKey: packagePath},
+		{"msg": "done"},
+	}, records)
+}
+
+func logRecords(t *testing.T, output *bytes.Buffer) []map[string]any {
+	t.Helper()
+	records := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		record := make(map[string]any)
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		delete(record, slog.TimeKey)
+		delete(record, slog.LevelKey)
+		records = append(records, record)
+	}
+	return records
+}
+
+type orderRepository struct {
+	logger *slog.Logger
+}
+
+func newOrderRepository(logger *slog.Logger) *orderRepository {
+	return &orderRepository{logger: logger}
+}
+
+type orderService struct {
+	logger     *slog.Logger
+	repository *orderRepository
+}
+
+func newOrderService(logger *slog.Logger, repository *orderRepository) *orderService {
+	return &orderService{logger: logger, repository: repository}
+}
@@ -0,0 +1,197 @@
+package features
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matzefriedrich/parsley/pkg/bootstrap"
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OptionsMonitor_reloads_options_and_notifies_callbacks_if_source_changes(t *testing.T) {
+
+	// Arrange
+	source := features.NewInMemorySource([]byte(`{"host": "a.local"}`))
+	sut, err := features.NewOptionsMonitor[serverOptions](features.OptionsMonitorConfig{PollInterval: time.Millisecond}, source)
+	assert.NoError(t, err)
+
+	changes := make(chan serverOptions, 1)
+	sut.OnChange(func(value serverOptions) {
+		changes <- value
+	})
+	removed := 0
+	remove := sut.OnChange(func(value serverOptions) {
+		removed++
+	})
+	remove()
+
+	_ = sut.Start(t.Context())
+	defer func() { _ = sut.Stop(t.Context()) }()
+
+	// Act
+	source.Update([]byte(`{"host": "b.local", "port": 9090}`))
+
+	// Assert
+	actual := receive(t, changes)
+	assert.Equal(t, "b.local", actual.Host)
+	assert.Equal(t, 9090, actual.Port)
+	assert.Equal(t, actual, sut.Current())
+	assert.Zero(t, removed)
+}
+
+func Test_OptionsMonitor_keeps_current_value_if_changed_options_are_invalid(t *testing.T) {
+
+	// Arrange
+	source := features.NewInMemorySource([]byte(`{"host": "a.local"}`))
+	failures := make(chan error, 1)
+	config := features.OptionsMonitorConfig{
+		PollInterval: time.Millisecond,
+		ReloadFailed: func(err error) {
+			failures <- err
+		},
+	}
+	sut, _ := features.NewOptionsMonitor[serverOptions](config, source)
+	sut.OnChange(func(value serverOptions) {
+		t.Errorf("unexpected change notification: %v", value)
+	})
+
+	_ = sut.Start(t.Context())
+	defer func() { _ = sut.Stop(t.Context()) }()
+
+	// Act
+	source.Update([]byte(`{"host": "b.local", "port": 0}`))
+
+	// Assert
+	err := receive(t, failures)
+	assert.ErrorIs(t, err, features.ErrInvalidOptions)
+	assert.Equal(t, "a.local", sut.Current().Host)
+}
+
+func Test_OptionsMonitor_reloads_options_if_modification_time_of_file_changes(t *testing.T) {
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "settings.json")
+	_ = os.WriteFile(path, []byte(`{"port": 1000}`), 0644)
+
+	registry := registration.NewServiceRegistry()
+	err := features.RegisterOptionsMonitor[serverOptions](registry, features.OptionsMonitorConfig{PollInterval: time.Millisecond}, features.JSONFileSource(path))
+	assert.NoError(t, err)
+
+	resolver := resolving.NewResolver(registry)
+	ctx := resolving.NewScopedContext(t.Context())
+	sut, _ := resolving.ResolveRequiredService[features.OptionsMonitor[serverOptions]](ctx, resolver)
+	options, _ := resolving.ResolveRequiredService[features.Options[serverOptions]](ctx, resolver)
+
+	changes := make(chan serverOptions, 1)
+	sut.OnChange(func(value serverOptions) {
+		changes <- value
+	})
+
+	_ = sut.Start(t.Context())
+	defer func() { _ = sut.Stop(t.Context()) }()
+
+	// Act
+	_ = os.WriteFile(path, []byte(`{"port": 2000}`), 0644)
+	modTime := time.Now().Add(time.Minute)
+	_ = os.Chtimes(path, modTime, modTime)
+
+	// Assert
+	actual := receive(t, changes)
+	assert.Equal(t, 2000, actual.Port)
+	assert.Equal(t, 2000, options.Value().Port)
+}
+
+func Test_RegisterOptionsMonitor_monitor_registered_as_hosted_service_is_started_by_host(t *testing.T) {
+
+	// Arrange
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	source := features.NewInMemorySource([]byte(`{"host": "a.local"}`))
+	config := features.OptionsMonitorConfig{PollInterval: time.Millisecond}
+
+	var actual serverOptions
+	appFactory := func(monitor features.OptionsMonitor[serverOptions]) bootstrap.Application {
+		return runFunc(func(ctx context.Context) error {
+			changes := make(chan serverOptions, 1)
+			monitor.OnChange(func(value serverOptions) {
+				changes <- value
+			})
+			source.Update([]byte(`{"host": "b.local"}`))
+			actual = receive(t, changes)
+			cancel()
+			return nil
+		})
+	}
+
+	host, err := bootstrap.NewApplicationBuilder(appFactory).WithModules(func(registry types.ServiceRegistry) error {
+		_ = features.RegisterOptionsMonitor[serverOptions](registry, config, source)
+		_ = features.RegisterOptionsMonitor[databaseOptions](registry, config, features.JSONSource([]byte(`{"name": "orders"}`)))
+		_ = bootstrap.RegisterHostedService[features.OptionsMonitor[serverOptions]](registry)
+		return bootstrap.RegisterHostedService[features.OptionsMonitor[databaseOptions]](registry)
+	}).Build()
+	assert.NoError(t, err)
+
+	// Act
+	err = host.Run(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "b.local", actual.Host)
+}
+
+func Test_RegisterOptionsMonitor_application_returns_from_run_without_being_signalled(t *testing.T) {
+
+	// Arrange
+	config := features.OptionsMonitorConfig{PollInterval: time.Millisecond}
+	appFactory := func(monitor features.OptionsMonitor[serverOptions]) bootstrap.Application {
+		return runFunc(func(ctx context.Context) error {
+			return nil
+		})
+	}
+
+	host, err := bootstrap.NewApplicationBuilder(appFactory).WithModules(func(registry types.ServiceRegistry) error {
+		return features.RegisterOptionsMonitor[serverOptions](registry, config, features.JSONSource([]byte(`{"host": "a.local"}`)))
+	}).Build()
+	assert.NoError(t, err)
+
+	done := make(chan error, 1)
+
+	// Act
+	go func() {
+		done <- host.Run(t.Context())
+	}()
+
+	// Assert
+	select {
+	case err = <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the application did not return from Run")
+	}
+}
+
+type runFunc func(ctx context.Context) error
+
+func (f runFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+func receive[T any](t *testing.T, values <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-values:
+		return value
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the options to be reloaded")
+	}
+	var zero T
+	return zero
+}
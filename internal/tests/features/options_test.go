@@ -0,0 +1,151 @@
+package features
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BindOptions_applies_defaults_and_sources_in_order_of_precedence(t *testing.T) {
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "settings.json")
+	_ = os.WriteFile(path, []byte(`{"host": "db.local", "port": 5432, "database": {"name": "orders"}}`), 0644)
+
+	t.Setenv("APP_PORT", "6543")
+	t.Setenv("APP_TAGS", "a, b")
+
+	flags := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags.Int("port", 1, "")
+	flags.Bool("verbose", false, "")
+	_ = flags.Parse([]string{"--verbose"})
+
+	// Act
+	actual, err := features.BindOptions[serverOptions](
+		features.JSONFileSource(path),
+		features.EnvironmentSource("APP_"),
+		features.FlagSetSource(flags))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "db.local", actual.Host)
+	assert.Equal(t, 6543, actual.Port)
+	assert.Equal(t, 5*time.Second, actual.Timeout)
+	assert.True(t, actual.Verbose)
+	assert.Equal(t, []string{"a", "b"}, actual.Tags)
+	assert.Equal(t, "orders", actual.Database.Name)
+	assert.Equal(t, uint(10), actual.Database.Connections)
+}
+
+func Test_BindOptions_returns_error_if_validation_fails(t *testing.T) {
+
+	// Arrange
+	source := features.JSONSource([]byte(`{"port": 70000, "database": {"connections": 0}}`))
+
+	// Act
+	_, err := features.BindOptions[serverOptions](source)
+
+	// Assert
+	assert.ErrorIs(t, err, features.ErrInvalidOptions)
+	assert.ErrorIs(t, err, errInvalidPort)
+	assert.ErrorIs(t, err, errNoConnections)
+}
+
+func Test_BindOptions_returns_error_if_value_cannot_be_parsed(t *testing.T) {
+
+	// Arrange
+	source := features.TagSource("env", func(key string) (string, bool) {
+		return "eighty", key == "PORT"
+	})
+
+	// Act
+	_, err := features.BindOptions[serverOptions](source)
+
+	// Assert
+	assert.ErrorIs(t, err, features.ErrCannotBindOptions)
+	assert.ErrorContains(t, errors.Unwrap(err), "cannot bind \"eighty\" to field serverOptions.Port")
+}
+
+func Test_BindOptions_returns_error_for_non_struct_type(t *testing.T) {
+
+	// Act
+	_, err := features.BindOptions[string]()
+
+	// Assert
+	assert.ErrorIs(t, err, features.ErrOptionsTypeMustBeStruct)
+}
+
+func Test_RegisterOptions_registers_bound_options_as_singleton(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = features.RegisterOptions[serverOptions](registry, features.JSONSource([]byte(`{"host": "example.com"}`)))
+
+	resolver := resolving.NewResolver(registry)
+	ctx := resolving.NewScopedContext(t.Context())
+
+	// Act
+	actual, err := resolving.ResolveRequiredService[features.Options[serverOptions]](ctx, resolver)
+	other, _ := resolving.ResolveRequiredService[features.Options[serverOptions]](resolving.NewScopedContext(t.Context()), resolver)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", actual.Value().Host)
+	assert.Equal(t, 8080, actual.Value().Port)
+	assert.Same(t, actual, other)
+}
+
+func Test_RegisterOptions_returns_error_and_does_not_register_invalid_options(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+
+	// Act
+	err := features.RegisterOptions[serverOptions](registry, features.JSONSource([]byte(`{"port": -1}`)))
+
+	// Assert
+	assert.ErrorIs(t, err, features.ErrInvalidOptions)
+	assert.False(t, registry.IsRegistered(types.MakeServiceType[features.Options[serverOptions]]()))
+}
+
+var (
+	errInvalidPort   = errors.New("port out of range")
+	errNoConnections = errors.New("at least one connection is required")
+)
+
+type serverOptions struct {
+	Host     string          `json:"host" default:"localhost"`
+	Port     int             `json:"port" env:"PORT" flag:"port" default:"8080"`
+	Timeout  time.Duration   `json:"timeout" default:"5s"`
+	Verbose  bool            `flag:"verbose"`
+	Tags     []string        `env:"TAGS"`
+	Database databaseOptions `json:"database"`
+}
+
+func (o serverOptions) Validate() error {
+	if o.Port < 1 || o.Port > 65535 {
+		return errInvalidPort
+	}
+	return nil
+}
+
+type databaseOptions struct {
+	Name        string `json:"name"`
+	Connections uint   `json:"connections" default:"10"`
+}
+
+func (o *databaseOptions) Validate() error {
+	if o.Connections == 0 {
+		return errNoConnections
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateActivatorSignature(t *testing.T) {
+
+	testCases := []struct {
+		name  string
+		code  string
+		valid bool
+	}{
+		{name: "interface return type", code: "func NewService() Service { return nil }", valid: true},
+		{name: "pointer return type with error", code: "func NewService() (*service, error) { return nil, nil }", valid: true},
+		{name: "context parameter", code: "func NewService(ctx context.Context, d Dependency) Service { return nil }", valid: true},
+		{name: "no return value", code: "func NewService() {}", valid: false},
+		{name: "second return value is not an error", code: "func NewService() (Service, bool) { return nil, false }", valid: false},
+		{name: "builtin return type", code: "func NewService() string { return \"\" }", valid: false},
+		{name: "builtin parameter type", code: "func NewService(name string) Service { return nil }", valid: false},
+		{name: "slice parameter type", code: "func NewService(handlers []Handler) Service { return nil }", valid: true},
+		{name: "named func return type", code: "func NewService() Handler { return nil }\n\ntype Handler func()", valid: true},
+		{name: "slice return type", code: "func NewService() []Service { return nil }", valid: false},
+		{name: "named basic return type", code: "func NewPort() Port { return 0 }\n\ntype Port int", valid: false},
+		{name: "named slice return type", code: "func NewNames() Names { return nil }\n\ntype Names []string", valid: false},
+		{name: "named basic parameter type", code: "func NewService(port Port) Service { return nil }\n\ntype Port int", valid: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Arrange
+			source := []byte("package main\n\nimport \"context\"\n\n" + testCase.code + "\n")
+			accessor := reflection.AstFromSource(source)
+			file, _ := accessor()
+			model, _ := generator.NewTemplateModelBuilder(file.File).Build()
+
+			// Act
+			err := generator.ValidateActivatorSignature(model.Functions[0], model.ValueTypes)
+
+			// Assert
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, generator.ErrInvalidActivatorFunction)
+			}
+		})
+	}
+}
+
+func Test_NewModuleTemplateModel_returns_error_for_activator_of_unsupported_service_type(t *testing.T) {
+
+	// Arrange
+	source := []byte("package main\n\n" +
+		"type Port int\n\n" +
+		"//parsley:register singleton\n" +
+		"func NewPort() Port { return 8080 }\n")
+	accessor := reflection.AstFromSource(source)
+	file, _ := accessor()
+	model, _ := generator.NewTemplateModelBuilder(file.File).Build()
+
+	// Act
+	_, err := generator.NewModuleTemplateModel(model, "RegisterMainModule")
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrInvalidActivatorFunction)
+}
@@ -1,8 +1,12 @@
 package generator
 
 import (
+	"errors"
 	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/matzefriedrich/parsley/internal/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"go/parser"
+	"go/token"
 	"io"
 	"testing"
 )
@@ -10,7 +14,7 @@ import (
 func Test_BootstrapGenerator_ScaffoldProjectFiles_generates_expected_project_files(t *testing.T) {
 
 	// Arrange
-	expectedProjectFiles := []string{"application.go", "main.go", "greeter.go"}
+	expectedProjectFiles := []string{"application.go", "main.go", "greeter.go", "greeter.mocks.g.go", "greet_command.go", "greet_command_test.go"}
 	memoryFiles := make(map[string]mocks.MemoryFile)
 	writerFuncFactory := func(targetFilename string) (io.WriteCloser, error) {
 		f, found := memoryFiles[targetFilename]
@@ -25,9 +29,11 @@ func Test_BootstrapGenerator_ScaffoldProjectFiles_generates_expected_project_fil
 	sut := generator.NewBootstrapGenerator(writerFuncFactory)
 
 	// Act
-	sut.ScaffoldProjectFiles()
+	files, err := sut.ScaffoldProjectFiles()
 
 	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, files, len(expectedProjectFiles))
 	for _, expectedProjectFile := range expectedProjectFiles {
 		file, found := memoryFiles[expectedProjectFile]
 		if !found {
@@ -40,3 +46,105 @@ func Test_BootstrapGenerator_ScaffoldProjectFiles_generates_expected_project_fil
 		t.Logf("Project file %s has content:\n%s", expectedProjectFile, content)
 	}
 }
+
+func Test_BootstrapGenerator_ScaffoldProjectFiles_generates_valid_source_files_for_all_templates(t *testing.T) {
+
+	for _, projectTemplate := range generator.ProjectTemplates() {
+		t.Run(projectTemplate.Name, func(t *testing.T) {
+
+			// Arrange
+			memoryFiles := make(map[string]mocks.MemoryFile)
+			writerFuncFactory := func(targetFilename string) (io.WriteCloser, error) {
+				f := mocks.NewMemoryFile()
+				memoryFiles[targetFilename] = f
+				return f, nil
+			}
+
+			sut := generator.NewBootstrapGenerator(writerFuncFactory, func(config *generator.BootstrapGeneratorOptions) {
+				config.Template = projectTemplate.Name
+			})
+
+			// Act
+			_, err := sut.ScaffoldProjectFiles()
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Contains(t, memoryFiles["greeter.go"].String(), "//go:generate parsley-cli generate mocks")
+			assert.Contains(t, memoryFiles["greeter.mocks.g.go"].String(), "func NewGreeterMock() *greeterMock")
+			for filename, file := range memoryFiles {
+				_, parseErr := parser.ParseFile(token.NewFileSet(), filename, file.String(), parser.AllErrors)
+				assert.NoError(t, parseErr)
+			}
+		})
+	}
+}
+
+func Test_BootstrapGenerator_ScaffoldProjectFiles_dry_run_does_not_write_files(t *testing.T) {
+
+	// Arrange
+	writes := 0
+	writerFuncFactory := func(targetFilename string) (io.WriteCloser, error) {
+		writes++
+		return mocks.NewMemoryFile(), nil
+	}
+
+	sut := generator.NewBootstrapGenerator(writerFuncFactory, func(config *generator.BootstrapGeneratorOptions) {
+		config.DryRun = true
+		config.FileExists = func(targetFilename string) (bool, error) {
+			return targetFilename == "main.go", nil
+		}
+		config.Force = true
+	})
+
+	// Act
+	files, err := sut.ScaffoldProjectFiles()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, writes)
+	assert.Contains(t, files, generator.ScaffoldedFile{Filename: "main.go", Exists: true})
+	assert.Contains(t, files, generator.ScaffoldedFile{Filename: "application.go", Exists: false})
+}
+
+func Test_BootstrapGenerator_ScaffoldProjectFiles_does_not_write_any_file_if_a_file_exists(t *testing.T) {
+
+	// Arrange
+	writes := 0
+	writerFuncFactory := func(targetFilename string) (io.WriteCloser, error) {
+		writes++
+		return mocks.NewMemoryFile(), nil
+	}
+
+	sut := generator.NewBootstrapGenerator(writerFuncFactory, func(config *generator.BootstrapGeneratorOptions) {
+		config.FileExists = func(targetFilename string) (bool, error) {
+			return targetFilename == "greeter.go", nil
+		}
+	})
+
+	// Act
+	_, err := sut.ScaffoldProjectFiles()
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrProjectFilesAlreadyExist)
+	assert.ErrorContains(t, errors.Unwrap(err), "greeter.go")
+	assert.Equal(t, 0, writes)
+}
+
+func Test_BootstrapGenerator_ScaffoldProjectFiles_returns_error_if_file_cannot_be_created(t *testing.T) {
+
+	// Arrange
+	writerFuncFactory := func(targetFilename string) (io.WriteCloser, error) {
+		if targetFilename == "main.go" {
+			return nil, errors.New("access denied")
+		}
+		return mocks.NewMemoryFile(), nil
+	}
+
+	sut := generator.NewBootstrapGenerator(writerFuncFactory)
+
+	// Act
+	_, err := sut.ScaffoldProjectFiles()
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to generate main.go: access denied")
+}
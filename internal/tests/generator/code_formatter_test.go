@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OrganizeImports_removes_unused_imports_and_groups_standard_library_packages(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n" +
+		"import (\n" +
+		"\"github.com/matzefriedrich/parsley/pkg/features\"\n" +
+		"\"fmt\"\n" +
+		"\"context\"\n" +
+		")\n" +
+		"type greeterMock struct { features.MockBase }\n" +
+		"func (m *greeterMock) SayHello(ctx context.Context) {}\n")
+
+	expected := "package main\n\n" +
+		"import (\n" +
+		"\t\"context\"\n\n" +
+		"\t\"github.com/matzefriedrich/parsley/pkg/features\"\n" +
+		")\n\n" +
+		"type greeterMock struct{ features.MockBase }\n\n" +
+		"func (m *greeterMock) SayHello(ctx context.Context) {}\n"
+
+	// Act
+	actual, err := generator.OrganizeImports(code)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func Test_OrganizeImports_adds_aliases_of_known_imports(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n\n" +
+		"import \"net/http\"\n\n" +
+		"func handle(w stdhttp.ResponseWriter, r *stdhttp.Request) {}\n")
+
+	// Act
+	actual, err := generator.OrganizeImports(code, generator.ImportModel{Alias: "stdhttp", Path: "net/http"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(actual), "import (\n\tstdhttp \"net/http\"\n)")
+}
+
+func Test_OrganizeImports_ignores_local_identifiers(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n\n" +
+		"import \"strings\"\n\n" +
+		"func join(strings []string) int { return len(strings) }\n" +
+		"func count(b builder) int { return b.strings }\n")
+
+	// Act
+	actual, err := generator.OrganizeImports(code, generator.ImportModel{Path: "strings"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotContains(t, string(actual), "import")
+}
+
+func Test_OrganizeImports_keeps_dot_imports(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n\n" +
+		"type sleeperMock struct{}\n" +
+		"func (m *sleeperMock) Sleep(d Duration) Time { return Now() }\n")
+
+	// Act
+	actual, err := generator.OrganizeImports(code, generator.ImportModel{Alias: ".", Path: "time"}, generator.ImportModel{Path: "strings"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(actual), "import (\n\t. \"time\"\n)")
+}
+
+func Test_FormatSource_reports_offending_generated_line(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n\nfunc broken( {\n}\n")
+
+	// Act
+	_, err := generator.FormatSource(code)
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotFormatGeneratedCode)
+	assert.ErrorContains(t, errors.Unwrap(err), "generated code line 3:14")
+	assert.ErrorContains(t, errors.Unwrap(err), "    3 | func broken( {")
+}
@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewConstructorTemplateModel_maps_fields_and_tags_to_parameters(t *testing.T) {
+
+	// Arrange
+	source, model := buildModel(t, "package main\n\n"+
+		"type service struct {\n"+
+		"	store    Store\n"+
+		"	clock    Clock `parsley:\"optional\"`\n"+
+		"	cache    Cache `json:\"cache\" parsley:\"name=redis\"`\n"+
+		"	fallback Cache `parsley:\"optional, name=memory\"`\n"+
+		"	counter  int   `parsley:\"-\"`\n"+
+		"	Type     string\n"+
+		"}\n")
+
+	// Act
+	actual, err := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"service"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, actual.Constructors, 1)
+
+	constructor := actual.Constructors[0]
+	assert.Equal(t, "NewService", constructor.Name)
+	assert.True(t, constructor.HasContext)
+	assert.True(t, constructor.HasResolver)
+	assert.True(t, constructor.HasErrorReturn)
+	assert.Equal(t, []generator.ConstructorParameterModel{
+		{Name: "store", Type: "Store"},
+		{Name: "cacheResolver", Type: "func(string) (Cache, error)"},
+		{Name: "fallbackResolver", Type: "func(string) (Cache, error)"},
+		{Name: "type1", Type: "string"},
+	}, constructor.Parameters)
+
+	assert.Len(t, constructor.Fields, 5)
+	assert.True(t, constructor.Fields[1].Optional)
+	assert.Equal(t, "redis", constructor.Fields[2].ServiceName)
+	assert.Equal(t, "Type", constructor.Fields[4].Name)
+}
+
+func Test_NewConstructorTemplateModel_adds_context_and_error_only_if_requested(t *testing.T) {
+
+	// Arrange
+	source, model := buildModel(t, "package main\n\n"+
+		"import \"context\"\n\n"+
+		"type greeter struct {\n"+
+		"	writer Writer\n"+
+		"}\n")
+
+	// Act
+	plain, plainErr := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"greeter"}})
+	extended, extendedErr := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"greeter"}, Context: true, Error: true})
+
+	// Assert
+	assert.NoError(t, plainErr)
+	assert.False(t, plain.Constructors[0].HasContext)
+	assert.False(t, plain.Constructors[0].HasResolver)
+	assert.False(t, plain.Constructors[0].HasErrorReturn)
+
+	assert.NoError(t, extendedErr)
+	assert.True(t, extended.Constructors[0].HasContext)
+	assert.False(t, extended.Constructors[0].HasResolver)
+	assert.True(t, extended.Constructors[0].HasErrorReturn)
+	assert.Equal(t, "context", extended.Packages.Context)
+}
+
+func Test_NewConstructorTemplateModel_avoids_package_names_used_by_the_source_file(t *testing.T) {
+
+	// Arrange
+	source, model := buildModel(t, "package main\n\n"+
+		"import types \"go/types\"\n\n"+
+		"type checker struct {\n"+
+		"	info *types.Info\n"+
+		"}\n")
+
+	// Act
+	actual, err := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"checker"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "types1", actual.Packages.Types)
+	assert.Equal(t, "*types.Info", actual.Constructors[0].Parameters[0].Type)
+}
+
+func Test_NewConstructorTemplateModel_returns_error_for_invalid_types_and_tags(t *testing.T) {
+
+	// Arrange
+	source, model := buildModel(t, "package main\n\n"+
+		"type service struct {\n"+
+		"	store Store `parsley:\"required\"`\n"+
+		"}\n\n"+
+		"type list[T any] struct{}\n")
+
+	// Act
+	_, unknownTypeErr := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"unknown"}})
+	_, genericErr := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"list"}})
+	_, tagErr := generator.NewConstructorTemplateModel(source, model, generator.ConstructorOptions{TypeNames: []string{"service"}})
+
+	// Assert
+	assert.ErrorIs(t, unknownTypeErr, generator.ErrCannotGenerateConstructor)
+	assert.ErrorIs(t, genericErr, generator.ErrCannotGenerateConstructor)
+	assert.ErrorIs(t, tagErr, generator.ErrCannotGenerateConstructor)
+}
+
+func buildModel(t *testing.T, code string) (*reflection.AstFileSource, *reflection.Model) {
+	source, err := reflection.AstFromSource([]byte(code))()
+	assert.NoError(t, err)
+	model, err := generator.NewTemplateModelBuilder(source.File).Build()
+	assert.NoError(t, err)
+	return source, model
+}
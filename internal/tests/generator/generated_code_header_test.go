@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithGeneratorVersion_inserts_header_after_generated_code_comment(t *testing.T) {
+
+	// Arrange
+	code := []byte("// Code generated by parsley-cli; DO NOT EDIT.\n\npackage main\n")
+
+	// Act
+	actual := generator.WithGeneratorVersion(code, "v1.6.0")
+
+	// Assert
+	assert.Equal(t, "// Code generated by parsley-cli; DO NOT EDIT.\n// Parsley CLI version: v1.6.0\n\npackage main\n", string(actual))
+	assert.Equal(t, "v1.6.0", generator.GeneratorVersionFrom(actual))
+	assert.True(t, generator.IsGeneratedByParsley(actual))
+}
+
+func Test_FindGeneratedFiles_returns_files_generated_by_parsley_with_recorded_versions(t *testing.T) {
+
+	// Arrange
+	dir := t.TempDir()
+	write := func(name string, content string) {
+		filePath := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		assert.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+	}
+	write("greeter.mocks.g.go", "// Code generated by parsley-cli; DO NOT EDIT.\n// Parsley CLI version: v1.5.0\n\npackage main\n")
+	write("legacy.proxy.g.go", "// Code generated by parsley-cli; DO NOT EDIT.\n\npackage main\n")
+	write("other.g.go", "// Code generated by stringer; DO NOT EDIT.\n\npackage main\n")
+	write("greeter.go", "// Code generated by parsley-cli; DO NOT EDIT.\n\npackage main\n")
+	write("services/service.mocks.g.go", "// Code generated by parsley-cli; DO NOT EDIT.\n// Parsley CLI version: v1.6.0\n\npackage services\n")
+	write("testdata/fixture.mocks.g.go", "// Code generated by parsley-cli; DO NOT EDIT.\n\npackage testdata\n")
+
+	// Act
+	flat, flatErr := generator.FindGeneratedFiles(dir, false)
+	recursive, recursiveErr := generator.FindGeneratedFiles(dir, true)
+
+	// Assert
+	assert.NoError(t, flatErr)
+	assert.Equal(t, []generator.GeneratedFile{
+		{Path: filepath.Join(dir, "greeter.mocks.g.go"), GeneratorVersion: "v1.5.0"},
+		{Path: filepath.Join(dir, "legacy.proxy.g.go")},
+	}, flat)
+
+	assert.NoError(t, recursiveErr)
+	assert.Equal(t, append(flat,
+		generator.GeneratedFile{Path: filepath.Join(dir, "services", "service.mocks.g.go"), GeneratorVersion: "v1.6.0"}), recursive)
+}
@@ -1 +1,35 @@
 package generator
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CodeFileGenerator_GenerateCode_does_not_write_output_if_generated_code_is_invalid(t *testing.T) {
+	// Arrange
+	source := []byte("package services\n\ntype Greeter interface {\n\tSayHello(name string) string\n}\n")
+
+	outputWriterRequested := false
+	sut, _ := generator.NewCodeFileGenerator("mocks", reflection.AstFromSource(source), func(config *generator.CodeFileGeneratorOptions) {
+		config.TemplateLoader = func(_ string) (string, error) {
+			return "package {{ .PackageName }}\n\nfunc broken() {\n\treturn (\n}\n", nil
+		}
+		config.OutputWriterFactory = func(kind string, source *reflection.AstFileSource) (io.WriteCloser, error) {
+			outputWriterRequested = true
+			return nil, errors.New("the output writer must not be requested")
+		}
+	})
+
+	// Act
+	err := sut.GenerateCode()
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotFormatGeneratedCode)
+	assert.ErrorContains(t, errors.Unwrap(err), "generated code line 5:1")
+	assert.False(t, outputWriterRequested)
+}
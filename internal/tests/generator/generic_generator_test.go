@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"github.com/matzefriedrich/parsley/internal/generator"
 	"github.com/matzefriedrich/parsley/internal/tests/mocks"
@@ -29,3 +30,37 @@ func Test_GenericGenerator_Generate_load_requested_template_via_loader_and_gener
 	actual := target.String()
 	assert.Equal(t, "Hello", actual)
 }
+
+func Test_GenericGenerator_Generate_returns_error_with_template_line_if_template_cannot_be_parsed(t *testing.T) {
+	// Arrange
+	sut := generator.NewGenericCodeGenerator(func(name string) (string, error) {
+		return "package main\n\n{{ .Msg | unknown }}\n", nil
+	})
+
+	target := mocks.NewMemoryFile()
+
+	// Act
+	err := sut.Generate("custom.gotmpl", struct{ Msg string }{Msg: "Hello"}, target)
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotParseTemplate)
+	assert.ErrorContains(t, errors.Unwrap(err), "custom.gotmpl:3")
+	assert.Empty(t, target.String())
+}
+
+func Test_GenericGenerator_Generate_returns_error_with_offending_line_and_writes_nothing_if_generated_code_is_invalid(t *testing.T) {
+	// Arrange
+	sut := generator.NewGenericCodeGenerator(func(name string) (string, error) {
+		return "package main\n\nfunc {{ .Msg }}() {\n", nil
+	})
+
+	target := mocks.NewMemoryFile()
+
+	// Act
+	err := sut.Generate("custom.gotmpl", struct{ Msg string }{Msg: "hello"}, target)
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotFormatGeneratedCode)
+	assert.ErrorContains(t, errors.Unwrap(err), "generated code line")
+	assert.Empty(t, target.String())
+}
@@ -23,3 +23,19 @@ func Test_GoFileAccessor_reads_filename_from_GOFILE_environment_variable_returns
 	assert.ErrorIs(t, err, generator.ErrFailedToObtainGeneratorSourceFile)
 	assert.Nil(t, source)
 }
+
+func Test_HasGenerateDirective_matches_generate_command_of_the_given_kind(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n" + "\n" +
+		"//go:generate go run github.com/matzefriedrich/parsley/cmd/parsley-cli generate proxy\n" +
+		"//go:generate mockgen -source greeter.go\n")
+
+	// Act
+	proxy := generator.HasGenerateDirective(code, "proxy")
+	mocks := generator.HasGenerateDirective(code, "mocks")
+
+	// Assert
+	assert.True(t, proxy)
+	assert.False(t, mocks)
+}
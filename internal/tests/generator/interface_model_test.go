@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewInterfaceTemplateModel_collects_methods_across_files(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n"+
+			"import \"context\"\n\n"+
+			"type userService struct{}\n\n"+
+			"// Find returns the user with the given id.\n"+
+			"func (s *userService) Find(ctx context.Context, id string) (*User, error) { return nil, nil }\n\n"+
+			"func (s *userService) validate(id string) bool { return true }\n"),
+		[]byte("package main\n\n"+
+			"import str \"strings\"\n\n"+
+			"func (s userService) Names(prefix str.Builder) []string { return nil }\n"),
+	)()
+
+	// Act
+	actual, err := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"userService"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "main", actual.PackageName)
+	assert.Len(t, actual.Interfaces, 1)
+
+	extracted := actual.Interfaces[0]
+	assert.Equal(t, "UserService", extracted.Name)
+	assert.Equal(t, "userService", extracted.TypeName)
+	assert.Equal(t, []generator.ExtractedMethodModel{
+		{Name: "Find", Signature: "(ctx context.Context, id string) (*User, error)", Doc: []string{"// Find returns the user with the given id."}},
+		{Name: "Names", Signature: "(prefix str.Builder) []string", Doc: []string{}},
+	}, extracted.Methods)
+}
+
+func Test_NewInterfaceTemplateModel_applies_method_filters(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n" +
+			"type Store struct{}\n\n" +
+			"func (s *Store) Get(key string) string { return \"\" }\n\n" +
+			"func (s *Store) Set(key string, value string) {}\n\n" +
+			"func (s *Store) Close() error { return nil }\n"),
+	)()
+
+	// Act
+	included, includeErr := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"Store"}, Include: []string{"Get"}})
+	excluded, excludeErr := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"Store"}, Name: "KeyValueStore", Exclude: []string{"Close"}})
+
+	// Assert
+	assert.NoError(t, includeErr)
+	assert.Equal(t, "StoreInterface", included.Interfaces[0].Name)
+	assert.Equal(t, []string{"Get"}, methodNames(included.Interfaces[0]))
+
+	assert.NoError(t, excludeErr)
+	assert.Equal(t, "KeyValueStore", excluded.Interfaces[0].Name)
+	assert.Equal(t, []string{"Get", "Set"}, methodNames(excluded.Interfaces[0]))
+}
+
+func Test_NewInterfaceTemplateModel_returns_error_for_invalid_types_and_filters(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n" +
+			"type Store struct{}\n\n" +
+			"func (s *Store) Get(key string) string { return \"\" }\n\n" +
+			"type Cache[T any] struct{}\n\n" +
+			"func (c *Cache[T]) Get(key string) T { var zero T; return zero }\n"),
+	)()
+
+	// Act
+	_, unknownTypeErr := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"Unknown"}})
+	_, genericErr := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"Cache"}})
+	_, unknownMethodErr := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"Store"}, Exclude: []string{"Set"}})
+	_, noMethodsErr := generator.NewInterfaceTemplateModel(sources, generator.InterfaceOptions{TypeNames: []string{"Store"}, Exclude: []string{"Get"}})
+
+	// Assert
+	assert.ErrorIs(t, unknownTypeErr, generator.ErrCannotExtractInterface)
+	assert.ErrorIs(t, genericErr, generator.ErrCannotExtractInterface)
+	assert.ErrorIs(t, unknownMethodErr, generator.ErrCannotExtractInterface)
+	assert.ErrorIs(t, noMethodsErr, generator.ErrCannotExtractInterface)
+}
+
+func Test_InterfaceNameFrom(t *testing.T) {
+	assert.Equal(t, "Greeter", generator.InterfaceNameFrom("greeter"))
+	assert.Equal(t, "GreeterInterface", generator.InterfaceNameFrom("Greeter"))
+}
+
+func methodNames(m generator.ExtractedInterfaceModel) []string {
+	names := make([]string, 0, len(m.Methods))
+	for _, method := range m.Methods {
+		names = append(names, method.Name)
+	}
+	return names
+}
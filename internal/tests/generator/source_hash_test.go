@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SourceHash_differs_for_different_splits_of_the_same_input(t *testing.T) {
+
+	// Act
+	a := generator.SourceHash([]byte("ab"), []byte("c"))
+	b := generator.SourceHash([]byte("a"), []byte("bc"))
+
+	// Assert
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, a, generator.SourceHash([]byte("ab"), []byte("c")))
+}
+
+func Test_WithSourceHash_inserts_header_after_generated_code_comment(t *testing.T) {
+
+	// Arrange
+	code := []byte("// Code generated by parsley-cli; DO NOT EDIT.\n\npackage main\n")
+
+	// Act
+	actual := generator.WithSourceHash(code, "abc")
+
+	// Assert
+	assert.Equal(t, "// Code generated by parsley-cli; DO NOT EDIT.\n// Source hash: abc\n\npackage main\n", string(actual))
+	assert.Equal(t, "abc", generator.SourceHashFrom(actual))
+}
+
+func Test_SourceHashFrom_returns_empty_string_if_header_is_missing(t *testing.T) {
+
+	// Arrange
+	code := []byte("package main\n\n// Source hash: abc\n")
+
+	// Act
+	actual := generator.SourceHashFrom(code)
+
+	// Assert
+	assert.Empty(t, actual)
+}
+
+func Test_PackageHashInputs_skips_files_generated_for_the_kind(t *testing.T) {
+
+	// Arrange
+	sources := []*reflection.AstFileSource{
+		{Filename: "services.go", Content: []byte("package app")},
+		{Filename: "services.wiring.g.go", Content: []byte("package app // generated")},
+		{Filename: "services.mocks.g.go", Content: []byte("package app // mocks")},
+	}
+
+	// Act
+	actual := generator.PackageHashInputs("wiring", sources)
+
+	// Assert
+	expected := [][]byte{[]byte("services.go"), []byte("package app"), []byte("services.mocks.g.go"), []byte("package app // mocks")}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/generator"
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWiringTemplateModel_resolves_dependencies_across_files(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n"+
+			"import \"github.com/matzefriedrich/parsley/pkg/types\"\n\n"+
+			"type Service interface{}\n\n"+
+			"func NewService(ctx context.Context) (Service, error) { return nil, nil }\n\n"+
+			"func configure(registry types.ServiceRegistry) error {\n"+
+			"	return registry.Register(NewService, types.LifetimeSingleton)\n"+
+			"}\n"),
+		[]byte("package main\n\n"+
+			"import parsley \"github.com/matzefriedrich/parsley/pkg/types\"\n\n"+
+			"//parsley:register transient\n"+
+			"func NewConsumer(s Service, r parsley.Resolver) *Consumer { return nil }\n"),
+	)()
+
+	// Act
+	actual, err := generator.NewWiringTemplateModel(sources, generator.WiringOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "WiredContainer", actual.ContainerName)
+	assert.Len(t, actual.Services, 2)
+
+	consumer := actual.Services[0]
+	assert.Equal(t, "NewConsumer", consumer.Name)
+	assert.Equal(t, "Transient", consumer.Lifetime)
+	assert.Equal(t, "p0, c", consumer.Arguments)
+
+	service := actual.Services[1]
+	assert.Equal(t, "Singleton", service.Lifetime)
+	assert.True(t, service.HasErrorReturn)
+	assert.Equal(t, "ctx", service.Arguments)
+}
+
+func Test_NewWiringTemplateModel_returns_error_for_missing_dependency(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n" +
+			"//parsley:register singleton\n" +
+			"func NewConsumer(s Service) *Consumer { return nil }\n"),
+	)()
+
+	// Act
+	_, err := generator.NewWiringTemplateModel(sources, generator.WiringOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotWireServices)
+}
+
+func Test_NewWiringTemplateModel_returns_error_for_circular_dependency(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n" +
+			"//parsley:register singleton\n" +
+			"func NewFoo(b Bar) Foo { return nil }\n\n" +
+			"//parsley:register singleton\n" +
+			"func NewBar(f Foo) Bar { return nil }\n"),
+	)()
+
+	// Act
+	_, err := generator.NewWiringTemplateModel(sources, generator.WiringOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotWireServices)
+	assert.ErrorContains(t, errors.Unwrap(err), "NewFoo -> NewBar -> NewFoo")
+}
+
+func Test_NewWiringTemplateModel_returns_error_for_unsupported_registration(t *testing.T) {
+
+	// Arrange
+	sources, _ := reflection.AstPackageFromSources(
+		[]byte("package main\n\n" +
+			"import \"github.com/matzefriedrich/parsley/pkg/types\"\n" +
+			"import \"github.com/matzefriedrich/parsley/pkg/registration\"\n\n" +
+			"func configure(registry types.ServiceRegistry) error {\n" +
+			"	return registration.RegisterInstance(registry, &Service{})\n" +
+			"}\n"),
+	)()
+
+	// Act
+	_, err := generator.NewWiringTemplateModel(sources, generator.WiringOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, generator.ErrCannotWireServices)
+}
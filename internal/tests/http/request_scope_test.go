@@ -0,0 +1,155 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	parsleyhttp "github.com/matzefriedrich/parsley/pkg/http"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestScope_resolves_handlers_per_request_and_closes_scoped_services(t *testing.T) {
+
+	// Arrange
+	tracker := &unitOfWorkTracker{}
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, func() *unitOfWorkTracker { return tracker })
+	_ = registration.RegisterScoped(registry, newUnitOfWork)
+	_ = registration.RegisterScoped(registry, newOrdersHandler)
+
+	mux := http.NewServeMux()
+	parsleyhttp.RegisterRoutes(mux, parsleyhttp.MapRoute[*ordersHandler]("GET /orders/{id}"))
+	sut := parsleyhttp.RequestScope(resolving.NewResolver(registry))(mux)
+
+	// Act
+	first := serve(sut, "/orders/1")
+	second := serve(sut, "/orders/2")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "order 1 (unit of work 1)", first.Body.String())
+	assert.Equal(t, "order 2 (unit of work 2)", second.Body.String())
+	assert.Equal(t, 2, tracker.closed)
+}
+
+func Test_RequestScope_reports_errors_of_scoped_services_that_fail_to_close(t *testing.T) {
+
+	// Arrange
+	closeErr := errors.New("rollback failed")
+	tracker := &unitOfWorkTracker{closeErr: closeErr}
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, func() *unitOfWorkTracker { return tracker })
+	_ = registration.RegisterScoped(registry, newUnitOfWork)
+	_ = registration.RegisterScoped(registry, newOrdersHandler)
+
+	var actual error
+	sut := parsleyhttp.RequestScope(resolving.NewResolver(registry), func(options *parsleyhttp.RequestScopeOptions) {
+		options.CloseFailed = func(r *http.Request, err error) {
+			actual = err
+		}
+	})(parsleyhttp.Handler[*ordersHandler]())
+
+	// Act
+	_ = serve(sut, "/orders")
+
+	// Assert
+	assert.ErrorIs(t, actual, closeErr)
+}
+
+func Test_Handler_writes_error_response_if_handler_cannot_be_resolved(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+
+	var actual error
+	sut := parsleyhttp.RequestScope(resolving.NewResolver(registry), func(options *parsleyhttp.RequestScopeOptions) {
+		resolveFailed := options.ResolveFailed
+		options.ResolveFailed = func(w http.ResponseWriter, r *http.Request, err error) {
+			actual = err
+			resolveFailed(w, r, err)
+		}
+	})(parsleyhttp.Handler[*ordersHandler]())
+
+	// Act
+	response := serve(sut, "/orders")
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.ErrorIs(t, actual, types.ErrServiceTypeNotRegistered)
+}
+
+func Test_Handler_writes_error_response_if_request_has_no_scope(t *testing.T) {
+
+	// Arrange
+	sut := parsleyhttp.Handler[*ordersHandler]()
+
+	// Act
+	response := serve(sut, "/orders")
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func Test_ResolverFrom_returns_resolver_of_request_scope(t *testing.T) {
+
+	// Arrange
+	resolver := resolving.NewResolver(registration.NewServiceRegistry())
+
+	var actual types.Resolver
+	var found bool
+	sut := parsleyhttp.RequestScope(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual, found = parsleyhttp.ResolverFrom(r.Context())
+	}))
+
+	// Act
+	_ = serve(sut, "/")
+
+	// Assert
+	assert.True(t, found)
+	assert.Same(t, resolver, actual)
+}
+
+func serve(handler http.Handler, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+type unitOfWorkTracker struct {
+	created  int
+	closed   int
+	closeErr error
+}
+
+type unitOfWork struct {
+	id      int
+	tracker *unitOfWorkTracker
+}
+
+func newUnitOfWork(tracker *unitOfWorkTracker) *unitOfWork {
+	tracker.created++
+	return &unitOfWork{id: tracker.created, tracker: tracker}
+}
+
+func (u *unitOfWork) Close() error {
+	u.tracker.closed++
+	return u.tracker.closeErr
+}
+
+type ordersHandler struct {
+	work *unitOfWork
+}
+
+func newOrdersHandler(work *unitOfWork) *ordersHandler {
+	return &ordersHandler{work: work}
+}
+
+func (h *ordersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, _ = fmt.Fprintf(w, "order %s (unit of work %d)", r.PathValue("id"), h.work.id)
+}
@@ -0,0 +1,77 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RuleRegistry_RulesBetween_returns_rules_after_from_up_to_including_to_ordered_by_version(t *testing.T) {
+
+	// Arrange
+	sut := migration.NewRuleRegistry(
+		migration.Rule{Version: "v1.7.0", Name: "c"},
+		migration.Rule{Version: "v1.5.2", Name: "b"},
+		migration.Rule{Version: "v1.4.0", Name: "skipped"},
+		migration.Rule{Version: "v1.5.0", Name: "a"})
+
+	// Act
+	rules, err := sut.RulesBetween("v1.4", "1.7")
+
+	// Assert
+	assert.NoError(t, err)
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func Test_RuleRegistry_RulesBetween_returns_error_for_invalid_range(t *testing.T) {
+
+	// Arrange
+	sut := migration.NewRuleRegistry()
+
+	// Act
+	_, rangeErr := sut.RulesBetween("v1.6", "v1.4")
+	_, versionErr := sut.RulesBetween("latest", "v1.4")
+
+	// Assert
+	assert.ErrorIs(t, rangeErr, migration.ErrInvalidVersionRange)
+	assert.ErrorIs(t, versionErr, migration.ErrInvalidVersion)
+}
+
+func Test_RuleRegistry_CheckCompatibility_reports_breaking_changes_regardless_of_version_order(t *testing.T) {
+
+	// Arrange
+	sut := migration.NewRuleRegistry(
+		migration.Rule{Version: "v1.5.0", Name: "lazy-value-context"})
+
+	// Act
+	compatibility, err := sut.CheckCompatibility("v1.6.0", "1.4.2")
+	unaffected, unaffectedErr := sut.CheckCompatibility("v1.5.1", "v1.6")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, compatibility.Compatible())
+	assert.Equal(t, "breaking changes between v1.4.2 and v1.6.0: v1.5.0 (lazy-value-context)", compatibility.String())
+
+	assert.NoError(t, unaffectedErr)
+	assert.True(t, unaffected.Compatible())
+	assert.Empty(t, unaffected.String())
+}
+
+func Test_RuleRegistry_CheckCompatibility_reports_different_major_versions(t *testing.T) {
+
+	// Arrange
+	sut := migration.NewRuleRegistry()
+
+	// Act
+	compatibility, err := sut.CheckCompatibility("v2.0.0", "v1.6.0")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, compatibility.Compatible())
+	assert.Equal(t, "the major versions of v1.6.0 and v2.0.0 differ", compatibility.String())
+}
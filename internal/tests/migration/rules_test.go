@@ -0,0 +1,172 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LazyValueContextRule_passes_context_parameter_of_enclosing_function(t *testing.T) {
+
+	// Arrange
+	source := "package main\n" + "\n" +
+		"import (\n" +
+		"	\"context\"\n" + "\n" +
+		"	\"github.com/matzefriedrich/parsley/pkg/features\"\n" +
+		")\n" + "\n" +
+		"type app struct {\n" +
+		"	greeter features.Lazy[Greeter]\n" +
+		"}\n" + "\n" +
+		"func (a *app) Run(ctx context.Context) error {\n" +
+		"	// say hello\n" +
+		"	a.greeter.Value().SayHello()\n" +
+		"	return nil\n" +
+		"}\n" + "\n" +
+		"func run(ctx context.Context, lazy features.Lazy[Greeter]) {\n" +
+		"	lazy.Value().SayHello()\n" +
+		"}\n"
+
+	// Act
+	actual := migrate(t, source, migration.LazyValueContextRule())
+
+	// Assert
+	assert.Contains(t, actual, "	// say hello\n	a.greeter.Value(ctx).SayHello()\n")
+	assert.Contains(t, actual, "	lazy.Value(ctx).SayHello()\n")
+}
+
+func Test_LazyValueContextRule_uses_context_TODO_and_adds_import_if_no_context_is_available(t *testing.T) {
+
+	// Arrange
+	source := "package main\n" + "\n" +
+		"import \"github.com/matzefriedrich/parsley/pkg/features\"\n" + "\n" +
+		"func run(lazy features.Lazy[Greeter], other Other) {\n" +
+		"	lazy.Value().SayHello()\n" +
+		"	other.Value()\n" +
+		"}\n"
+
+	// Act
+	actual := migrate(t, source, migration.LazyValueContextRule())
+
+	// Assert
+	expected := "package main\n" + "\n" +
+		"import (\n" +
+		"	\"context\"\n" + "\n" +
+		"	\"github.com/matzefriedrich/parsley/pkg/features\"\n" +
+		")\n" + "\n" +
+		"func run(lazy features.Lazy[Greeter], other Other) {\n" +
+		"	lazy.Value(context.TODO()).SayHello()\n" +
+		"	other.Value()\n" +
+		"}\n"
+	assert.Equal(t, expected, actual)
+}
+
+func Test_LazyValueContextRule_adds_context_import_to_standard_library_group(t *testing.T) {
+
+	// Arrange
+	source := "package main\n" + "\n" +
+		"import (\n" +
+		"	\"fmt\"\n" + "\n" +
+		"	\"github.com/matzefriedrich/parsley/pkg/features\"\n" +
+		")\n" + "\n" +
+		"func run(lazy features.Lazy[fmt.Stringer]) {\n" +
+		"	fmt.Println(lazy.Value())\n" +
+		"}\n"
+
+	// Act
+	actual := migrate(t, source, migration.LazyValueContextRule())
+
+	// Assert
+	assert.Contains(t, actual, "import (\n	\"context\"\n	\"fmt\"\n\n	\"github.com/matzefriedrich/parsley/pkg/features\"\n)\n")
+	assert.Contains(t, actual, "	fmt.Println(lazy.Value(context.TODO()))\n")
+}
+
+func Test_RegisterNamedWithoutContextRule_removes_context_argument(t *testing.T) {
+
+	// Arrange
+	source := "package main\n" + "\n" +
+		"import (\n" +
+		"	\"context\"\n" + "\n" +
+		"	\"github.com/matzefriedrich/parsley/pkg/features\"\n" +
+		"	\"github.com/matzefriedrich/parsley/pkg/registration\"\n" +
+		"	\"github.com/matzefriedrich/parsley/pkg/types\"\n" +
+		")\n" + "\n" +
+		"func configure(registry types.ServiceRegistry) error {\n" +
+		"	return features.RegisterNamed[DataService](context.Background(), registry,\n" +
+		"		registration.NamedServiceRegistration(\"remote\", NewRemoteDataService, types.LifetimeSingleton))\n" +
+		"}\n" + "\n" +
+		"func configureMigrated(registry types.ServiceRegistry) error {\n" +
+		"	return features.RegisterNamed[DataService](registry)\n" +
+		"}\n"
+
+	// Act
+	actual := migrate(t, source, migration.RegisterNamedWithoutContextRule())
+
+	// Assert
+	assert.Contains(t, actual, "	return features.RegisterNamed[DataService](registry,\n")
+	assert.Contains(t, actual, "func configureMigrated(registry types.ServiceRegistry) error {\n	return features.RegisterNamed[DataService](registry)\n")
+}
+
+func Test_RegisterListWithoutContextRule_removes_context_argument(t *testing.T) {
+
+	// Arrange
+	source := "package main\n" + "\n" +
+		"import \"github.com/matzefriedrich/parsley/pkg/features\"\n" + "\n" +
+		"func configure(ctx context.Context, registry types.ServiceRegistry) error {\n" +
+		"	return features.RegisterList[Greeter](ctx, registry)\n" +
+		"}\n"
+
+	// Act
+	actual := migrate(t, source, migration.RegisterListWithoutContextRule())
+
+	// Assert
+	assert.Contains(t, actual, "	return features.RegisterList[Greeter](registry)\n")
+}
+
+func Test_ServiceTypeFromErrorRule_replaces_calls_with_MustServiceTypeFrom(t *testing.T) {
+
+	// Arrange
+	source := "package main\n" + "\n" +
+		"import (\n" +
+		"	\"reflect\"\n" + "\n" +
+		"	parsley \"github.com/matzefriedrich/parsley/pkg/types\"\n" +
+		")\n" + "\n" +
+		"func keyOf(t reflect.Type) parsley.ServiceKey {\n" +
+		"	return parsley.ServiceTypeFrom(t).LookupKey()\n" +
+		"}\n"
+
+	// Act
+	actual := migrate(t, source, migration.ServiceTypeFromErrorRule())
+
+	// Assert
+	assert.Contains(t, actual, "	return parsley.MustServiceTypeFrom(t).LookupKey()\n")
+}
+
+func Test_MigratePackages_returns_no_changes_if_rules_do_not_apply(t *testing.T) {
+
+	// Arrange
+	p := &migration.Package{Dir: "."}
+	_, err := migration.ParseFile(p, "main.go", []byte("package main\n\nfunc main() {}\n"))
+	assert.NoError(t, err)
+
+	// Act
+	changes, err := migration.MigratePackages([]*migration.Package{p}, []migration.Rule{migration.LazyValueContextRule()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func migrate(t *testing.T, source string, rules ...migration.Rule) string {
+	t.Helper()
+	p := &migration.Package{Dir: "."}
+	_, err := migration.ParseFile(p, "main.go", []byte(source))
+	assert.NoError(t, err)
+	changes, err := migration.MigratePackages([]*migration.Package{p}, rules)
+	assert.NoError(t, err)
+	if !assert.Len(t, changes, 1) {
+		return ""
+	}
+	assert.Equal(t, source, string(changes[0].Original))
+	return string(changes[0].Migrated)
+}
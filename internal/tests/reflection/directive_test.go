@@ -0,0 +1,44 @@
+package reflection
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/reflection"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDirective_parses_name_arguments_and_options(t *testing.T) {
+
+	// Arrange
+	comment := reflection.Comment{Text: "//parsley:register singleton as=Greeter name=polite exclude=Close,String"}
+
+	// Act
+	actual, ok := reflection.ParseDirective(comment)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, "register", actual.Name)
+	assert.True(t, actual.HasArgument("singleton"))
+	assert.Equal(t, "Greeter", actual.Option("as"))
+	assert.Equal(t, "polite", actual.Option("name"))
+	assert.Equal(t, []string{"Close", "String"}, actual.Options["exclude"])
+}
+
+func Test_ParseDirective_returns_false_for_regular_comments(t *testing.T) {
+
+	// Arrange
+	comments := []reflection.Comment{
+		{Text: "// Greeter says hello"},
+		{Text: "//parsley:"},
+		{Text: "// parsley:mock"},
+	}
+
+	for _, comment := range comments {
+
+		// Act
+		_, ok := reflection.ParseDirective(comment)
+
+		// Assert
+		assert.False(t, ok, comment.Text)
+	}
+}
@@ -53,3 +53,31 @@ func Test_Parameter_MatchesType_returns_false_if_type_does_not_match_expected_ty
 	// Assert
 	assert.False(t, actual)
 }
+
+func Test_Model_AddImport_renames_conflicting_source_import(t *testing.T) {
+
+	// Arrange
+	flagType := &reflection.ParameterType{Name: "Flag", SelectorName: "features"}
+	sut := &reflection.Model{
+		Imports: []string{"example.com/app/features"},
+		Interfaces: []reflection.Interface{{
+			Name:    "Service",
+			Methods: []reflection.Method{{Name: "Enabled", Parameters: []reflection.Parameter{{Name: "flag", Type: flagType}}}},
+		}},
+	}
+
+	// Act
+	sut.AddImport("github.com/matzefriedrich/parsley/pkg/features")
+
+	// Assert
+	assert.Equal(t, "features1", sut.ImportName("example.com/app/features"))
+	assert.Equal(t, "features", sut.ImportName("github.com/matzefriedrich/parsley/pkg/features"))
+	assert.Equal(t, "features1", flagType.SelectorName)
+}
+
+func Test_PackageNameFromImportPath(t *testing.T) {
+	assert.Equal(t, "http", reflection.PackageNameFromImportPath("net/http"))
+	assert.Equal(t, "yaml", reflection.PackageNameFromImportPath("gopkg.in/yaml.v3"))
+	assert.Equal(t, "chi", reflection.PackageNameFromImportPath("github.com/go-chi/chi/v5"))
+	assert.Equal(t, "bar", reflection.PackageNameFromImportPath("github.com/foo/go-bar"))
+}
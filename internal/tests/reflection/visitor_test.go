@@ -91,6 +91,46 @@ func Test_FileWalker_WalkSyntaxTree_build_Model_collect_struct_types(t *testing.
 	assert.NotNil(t, model)
 }
 
+func Test_FileWalker_WalkSyntaxTree_build_Model_collect_struct_fields(t *testing.T) {
+
+	// Arrange
+	fileVisitor := reflection2.NewFileVisitor()
+	sut := reflection2.NewSyntaxWalker(fileVisitor)
+
+	source := "" +
+		"package main\n\n" +
+		"type MyStruct struct {\n" +
+		"	a, b   Greeter\n" +
+		"	clock  Clock `parsley:\"optional\"`\n" +
+		"	*io.PipeReader\n" +
+		"}\n\n" +
+		"type List[T any] struct{}\n"
+
+	fileAccessor := reflection2.AstFromSource([]byte(source))
+	file, _ := fileAccessor()
+
+	// Act
+	err := sut.WalkSyntaxTree(file.File)
+
+	// Assert
+	assert.NoError(t, err)
+
+	model, _ := fileVisitor.Model()
+	assert.Len(t, model.Structs, 2)
+
+	fields := model.Structs[0].Fields
+	assert.Len(t, fields, 4)
+	assert.Equal(t, "b", fields[1].Name)
+	assert.Equal(t, "Greeter", file.Text(fields[1].Type))
+	assert.Equal(t, `parsley:"optional"`, fields[2].Tag)
+	assert.Equal(t, "PipeReader", fields[3].Name)
+	assert.True(t, fields[3].Embedded)
+	assert.Equal(t, "*io.PipeReader", file.Text(fields[3].Type))
+
+	assert.False(t, model.Structs[0].Generic)
+	assert.True(t, model.Structs[1].Generic)
+}
+
 func Test_FileWalker_WalkSyntaxTree_build_Model_collect_complex_interface(t *testing.T) {
 
 	// Arrange
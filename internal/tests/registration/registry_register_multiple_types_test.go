@@ -155,6 +155,35 @@ func Test_Registry_register_multiple_types_mixed_lifetime_scopes_2(t *testing.T)
 	assert.Equal(t, reflect.ValueOf(foo4Instance1).Pointer(), reflect.ValueOf(foo4Instance2).Pointer())
 }
 
+func Test_Registry_register_activator_functions_of_generic_function_for_different_types(t *testing.T) {
+
+	// Arrange
+	sut := registration.NewServiceRegistry()
+	_ = registration.RegisterTransient(sut, func() *foo3 { return &foo3{name: "foo3"} })
+	_ = registration.RegisterTransient(sut, func() *foo4 { return &foo4{name: "foo4"} })
+
+	// Act
+	foo3Err := registerAsMultiFoo[*foo3](sut)
+	foo4Err := registerAsMultiFoo[*foo4](sut)
+	duplicateErr := registerAsMultiFoo[*foo4](sut)
+
+	r := resolving.NewResolver(sut)
+	resolvedServices, err := resolving.ResolveRequiredServices[multiFoo](t.Context(), r)
+
+	// Assert
+	assert.NoError(t, foo3Err)
+	assert.NoError(t, foo4Err)
+	assert.ErrorIs(t, duplicateErr, types.ErrTypeAlreadyRegistered)
+	assert.NoError(t, err)
+	assert.Len(t, resolvedServices, 2)
+}
+
+func registerAsMultiFoo[T multiFoo](registry types.ServiceRegistry) error {
+	return registration.RegisterTransient(registry, func(service T) multiFoo {
+		return service
+	})
+}
+
 type multiFoo interface {
 	Bar() string
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/matzefriedrich/parsley/internal/tests/features"
 	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
 	"github.com/matzefriedrich/parsley/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -83,6 +84,103 @@ func Test_Validator_Validate_detects_circular_dependencies_and_returns_error(t *
 	}
 }
 
+func Test_Validator_Validate_conditional_registrations_matching_each_consumer_once_does_not_return_error(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, newGreeter)
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newFormalGreeter, registration.WhenInjectedInto[*testService]()))
+	_ = registration.RegisterTransient(registry, newTestService, newOtherTestService)
+
+	sut := registration.NewServiceRegistrationsValidator()
+
+	// Act
+	err := sut.Validate(registry)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func Test_Validator_Validate_detects_consumer_matching_multiple_conditional_registrations(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newGreeter, registration.WhenInjectedInto[*testService]()))
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newFormalGreeter, registration.WhenInjectedInto[*testService]()))
+	_ = registration.RegisterTransient(registry, newTestService)
+
+	sut := registration.NewServiceRegistrationsValidator()
+
+	// Act
+	err := sut.Validate(registry)
+
+	// Assert
+	assert.ErrorIs(t, err, registration.ErrAmbiguousConditionalRegistrationsDetected)
+	assert.ErrorIs(t, err, types.ErrAmbiguousConditionalRegistrations)
+}
+
+func Test_Validator_Validate_detects_consumer_matching_no_conditional_registration(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newFormalGreeter, registration.WhenInjectedInto[*testService]()))
+	_ = registration.RegisterTransient(registry, newOtherTestService)
+
+	sut := registration.NewServiceRegistrationsValidator()
+
+	// Act
+	err := sut.Validate(registry)
+
+	// Assert
+	assert.ErrorIs(t, err, registration.ErrAmbiguousConditionalRegistrationsDetected)
+	assert.ErrorIs(t, err, types.ErrServiceTypeNotRegistered)
+}
+
+func Test_Validator_Validate_evaluates_conditions_with_transitive_consumers(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newGreeter, whenResolvedFor[*testServiceConsumer]()))
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newFormalGreeter, registration.WhenInjectedInto[*testService]()))
+	_ = registration.RegisterTransient(registry, newTestService, newTestServiceConsumer)
+
+	sut := registration.NewServiceRegistrationsValidator()
+
+	_, resolveErr := resolving.ResolveRequiredService[*testServiceConsumer](t.Context(), resolving.NewResolver(registry))
+
+	// Act
+	err := sut.Validate(registry)
+
+	// Assert
+	assert.ErrorIs(t, resolveErr, types.ErrAmbiguousConditionalRegistrations)
+	assert.ErrorIs(t, err, registration.ErrAmbiguousConditionalRegistrationsDetected)
+	assert.ErrorIs(t, err, types.ErrAmbiguousConditionalRegistrations)
+	assert.ErrorContains(t, errors.Unwrap(err), "injected into testService required by testServiceConsumer")
+}
+
+// whenResolvedFor creates an InjectionCondition that is met if the service type C is any of the consumers of the dependency.
+func whenResolvedFor[C any]() types.InjectionCondition {
+	consumerType := types.MakeServiceType[C]()
+	return func(consumers []types.ServiceType) bool {
+		for _, consumer := range consumers {
+			if consumer.LookupKey() == consumerType.LookupKey() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type testServiceConsumer struct {
+	service *testService
+}
+
+func newTestServiceConsumer(service *testService) *testServiceConsumer {
+	return &testServiceConsumer{
+		service: service,
+	}
+}
+
 type testService struct {
 	greeter features.Greeter
 }
@@ -112,3 +210,21 @@ func newTestService3(other *testService2) *testService3 {
 		other: other,
 	}
 }
+
+type otherTestService struct {
+	greeter features.Greeter
+}
+
+func newOtherTestService(greeter features.Greeter) *otherTestService {
+	return &otherTestService{
+		greeter: greeter,
+	}
+}
+
+func newGreeter() features.Greeter {
+	return features.NewGreeterMock()
+}
+
+func newFormalGreeter() features.Greeter {
+	return features.NewGreeterMock()
+}
@@ -0,0 +1,147 @@
+package resolving
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resolver_resolves_conditional_registration_for_matching_consumer(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, newPrimaryDatabase)
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newReadReplicaDatabase, registration.WhenInjectedInto[*reportService]()))
+	_ = registration.RegisterTransient(registry, newReportService, newOrderService)
+
+	sut := resolving.NewResolver(registry)
+	ctx := resolving.NewScopedContext(t.Context())
+
+	// Act
+	reports, reportsErr := resolving.ResolveRequiredService[*reportService](ctx, sut)
+	orders, ordersErr := resolving.ResolveRequiredService[*orderService](ctx, sut)
+
+	// Assert
+	assert.NoError(t, reportsErr)
+	assert.NoError(t, ordersErr)
+	assert.Equal(t, "replica", reports.db.Name())
+	assert.Equal(t, "primary", orders.db.Name())
+}
+
+func Test_Resolver_resolves_conditional_registration_matching_consumer_chain(t *testing.T) {
+
+	// Arrange
+	whenResolvedForReports := func(consumers []types.ServiceType) bool {
+		for _, consumer := range consumers {
+			if consumer.LookupKey() == types.MakeServiceType[*reportService]().LookupKey() {
+				return true
+			}
+		}
+		return false
+	}
+
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, newPrimaryDatabase)
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newReadReplicaDatabase, whenResolvedForReports))
+	_ = registration.RegisterTransient(registry, newReportQuery, newReportServiceWithQuery)
+
+	sut := resolving.NewResolver(registry)
+
+	// Act
+	reports, err := resolving.ResolveRequiredService[*reportService](resolving.NewScopedContext(t.Context()), sut)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", reports.query.db.Name())
+}
+
+func Test_Resolver_ignores_conditional_registration_if_service_is_resolved_directly(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, newPrimaryDatabase)
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newReadReplicaDatabase, registration.WhenInjectedInto[*reportService]()))
+
+	sut := resolving.NewResolver(registry)
+
+	// Act
+	db, err := resolving.ResolveRequiredService[database](resolving.NewScopedContext(t.Context()), sut)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", db.Name())
+}
+
+func Test_Resolver_returns_error_if_multiple_conditional_registrations_match_consumer(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, newPrimaryDatabase)
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newReadReplicaDatabase, registration.WhenInjectedInto[*reportService]()))
+	_ = registration.RegisterSingleton(registry, registration.Conditional(newArchiveDatabase, registration.WhenInjectedInto[*reportService]()))
+	_ = registration.RegisterTransient(registry, newReportService)
+
+	sut := resolving.NewResolver(registry)
+
+	// Act
+	_, err := resolving.ResolveRequiredService[*reportService](resolving.NewScopedContext(t.Context()), sut)
+
+	// Assert
+	assert.ErrorIs(t, err, types.ErrAmbiguousConditionalRegistrations)
+}
+
+type database interface {
+	Name() string
+}
+
+type namedDatabase struct {
+	name string
+}
+
+func (d *namedDatabase) Name() string {
+	return d.name
+}
+
+func newPrimaryDatabase() database {
+	return &namedDatabase{name: "primary"}
+}
+
+func newReadReplicaDatabase() database {
+	return &namedDatabase{name: "replica"}
+}
+
+func newArchiveDatabase() database {
+	return &namedDatabase{name: "archive"}
+}
+
+type reportQuery struct {
+	db database
+}
+
+func newReportQuery(db database) *reportQuery {
+	return &reportQuery{db: db}
+}
+
+type reportService struct {
+	db    database
+	query *reportQuery
+}
+
+func newReportService(db database) *reportService {
+	return &reportService{db: db}
+}
+
+func newReportServiceWithQuery(query *reportQuery) *reportService {
+	return &reportService{query: query}
+}
+
+type orderService struct {
+	db database
+}
+
+func newOrderService(db database) *orderService {
+	return &orderService{db: db}
+}
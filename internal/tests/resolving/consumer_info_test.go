@@ -0,0 +1,61 @@
+package resolving
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resolver_passes_consumer_info_to_activator_functions_and_creates_instance_per_consumer(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterSingleton(registry, func(consumer types.ConsumerInfo) *consumerTag {
+		return &consumerTag{name: consumer.Name(), packagePath: consumer.PackagePath()}
+	})
+	_ = registration.RegisterScoped(registry, func(tag *consumerTag) tagged { return &taggedService{tag: tag} })
+	_ = registration.RegisterScoped(registry, func(tag *consumerTag, other tagged) *taggedConsumer {
+		return &taggedConsumer{tag: tag, other: other}
+	})
+
+	resolver := resolving.NewResolver(registry)
+	ctx := resolving.NewScopedContext(t.Context())
+
+	// Act
+	actual, err := resolving.ResolveRequiredService[*taggedConsumer](ctx, resolver)
+	direct, _ := resolving.ResolveRequiredService[*consumerTag](ctx, resolver)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, registration.NewServiceRegistrationsValidator().Validate(registry))
+
+	const packagePath = "github.com/matzefriedrich/parsley/internal/tests/resolving"
+	assert.Equal(t, &consumerTag{name: "taggedConsumer", packagePath: packagePath}, actual.tag)
+	assert.Equal(t, &consumerTag{name: "tagged", packagePath: packagePath}, actual.other.Tag())
+	assert.Equal(t, &consumerTag{}, direct)
+}
+
+type consumerTag struct {
+	name        string
+	packagePath string
+}
+
+type tagged interface {
+	Tag() *consumerTag
+}
+
+type taggedService struct {
+	tag *consumerTag
+}
+
+func (s *taggedService) Tag() *consumerTag {
+	return s.tag
+}
+
+type taggedConsumer struct {
+	tag   *consumerTag
+	other tagged
+}
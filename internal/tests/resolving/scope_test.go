@@ -0,0 +1,132 @@
+package resolving
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/stretchr/testify/assert"
+)
+
+type scopeTestKey string
+
+func Test_ResolveScoped_returns_same_instance_within_scope(t *testing.T) {
+
+	// Arrange
+	activations := 0
+	activator := func() (*int, error) {
+		activations++
+		return &activations, nil
+	}
+
+	scope := resolving.NewScopedContext(t.Context())
+	otherScope := resolving.NewScopedContext(t.Context())
+
+	// Act
+	first, _ := resolving.ResolveScoped(scope, scopeTestKey("counter"), activator)
+	second, _ := resolving.ResolveScoped(scope, scopeTestKey("counter"), activator)
+	_, _ = resolving.ResolveScoped(otherScope, scopeTestKey("counter"), activator)
+
+	// Assert
+	assert.Same(t, first, second)
+	assert.Equal(t, 2, activations)
+}
+
+func Test_ResolveScoped_without_scope_calls_activator_for_each_request(t *testing.T) {
+
+	// Arrange
+	activations := 0
+	activator := func() (int, error) {
+		activations++
+		return activations, nil
+	}
+
+	// Act
+	_, _ = resolving.ResolveScoped(t.Context(), scopeTestKey("counter"), activator)
+	_, _ = resolving.ResolveScoped(t.Context(), scopeTestKey("counter"), activator)
+
+	// Assert
+	assert.Equal(t, 2, activations)
+}
+
+func Test_CloseScope_closes_scoped_instances_in_reverse_order_of_creation(t *testing.T) {
+
+	// Arrange
+	closed := make([]string, 0)
+	closeErr := errors.New("connection reset")
+
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterScoped(registry, func() *scopedConnection {
+		return &scopedConnection{name: "connection", closed: &closed, err: closeErr}
+	})
+	_ = registration.RegisterScoped(registry, func(c *scopedConnection) *scopedRepository {
+		return &scopedRepository{scopedConnection: &scopedConnection{name: "repository", closed: &closed}}
+	})
+
+	resolver := resolving.NewResolver(registry)
+	scope := resolving.NewScopedContext(t.Context())
+	first, _ := resolving.ResolveRequiredService[*scopedRepository](scope, resolver)
+	_, _ = resolving.ResolveScoped(scope, scopeTestKey("cache"), func() (*scopedConnection, error) {
+		return &scopedConnection{name: "cache", closed: &closed}, nil
+	})
+
+	// Act
+	err := resolving.CloseScope(scope)
+	second, _ := resolving.ResolveRequiredService[*scopedRepository](scope, resolver)
+
+	// Assert
+	assert.ErrorIs(t, err, closeErr)
+	assert.Equal(t, []string{"cache", "repository", "connection"}, closed)
+	assert.NotSame(t, first, second)
+}
+
+func Test_ResolveScoped_shares_scoped_instances_with_resolver(t *testing.T) {
+
+	// Arrange
+	registry := registration.NewServiceRegistry()
+	_ = registration.RegisterScoped(registry, newScopedSession)
+
+	resolver := resolving.NewResolver(registry)
+	scope := resolving.NewScopedContext(t.Context())
+	otherScope := resolving.NewScopedContext(t.Context())
+
+	key := resolving.ScopedServiceKey[*scopedSession](newScopedSession)
+	activator := func() (*scopedSession, error) {
+		return newScopedSession(), nil
+	}
+
+	// Act
+	resolved, _ := resolving.ResolveRequiredService[*scopedSession](scope, resolver)
+	generated, _ := resolving.ResolveScoped(scope, key, activator)
+	otherGenerated, _ := resolving.ResolveScoped(otherScope, key, activator)
+	otherResolved, _ := resolving.ResolveRequiredService[*scopedSession](otherScope, resolver)
+
+	// Assert
+	assert.Same(t, resolved, generated)
+	assert.Same(t, otherGenerated, otherResolved)
+	assert.NotSame(t, resolved, otherResolved)
+}
+
+type scopedSession struct {
+	id int
+}
+
+func newScopedSession() *scopedSession {
+	return &scopedSession{}
+}
+
+type scopedConnection struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (c *scopedConnection) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+type scopedRepository struct {
+	*scopedConnection
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/matzefriedrich/parsley/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnifiedDiff_returns_empty_string_for_equal_input(t *testing.T) {
+
+	// Arrange
+	text := []byte("a\nb\nc\n")
+
+	// Act
+	actual := utils.UnifiedDiff("a.go", "b.go", text, text)
+
+	// Assert
+	assert.Empty(t, actual)
+}
+
+func Test_UnifiedDiff_returns_hunks_with_context_lines(t *testing.T) {
+
+	// Arrange
+	a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n")
+	b := []byte("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n15\n16\n")
+
+	expected := "--- a.go\n+++ b.go\n" +
+		"@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n" +
+		"@@ -11,5 +11,5 @@\n 11\n 12\n 13\n-14\n 15\n+16\n"
+
+	// Act
+	actual := utils.UnifiedDiff("a.go", "b.go", a, b)
+
+	// Assert
+	assert.Equal(t, expected, actual)
+}
+
+func Test_UnifiedDiff_reports_added_file(t *testing.T) {
+
+	// Act
+	actual := utils.UnifiedDiff("a.go", "b.go", nil, []byte("package main\n"))
+
+	// Assert
+	assert.Equal(t, "--- a.go\n+++ b.go\n@@ -0,0 +1,1 @@\n+package main\n", actual)
+}
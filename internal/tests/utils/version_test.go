@@ -52,6 +52,9 @@ func Test_VersionInfo_Equal(t *testing.T) {
 
 func Test_ApplicationVersion_has_valid_application_version_string(t *testing.T) {
 	// Arrange
+	previousVersion := utils.VersionString
+	utils.VersionString = "1.6.1"
+	t.Cleanup(func() { utils.VersionString = previousVersion })
 
 	// Act
 	version, err := utils.ApplicationVersion()
@@ -60,3 +63,17 @@ func Test_ApplicationVersion_has_valid_application_version_string(t *testing.T)
 	assert.NoError(t, err)
 	assert.NotNil(t, version)
 }
+
+func Test_ApplicationVersion_returns_error_for_development_build_without_version_string(t *testing.T) {
+	// Arrange
+	previousVersion := utils.VersionString
+	utils.VersionString = ""
+	t.Cleanup(func() { utils.VersionString = previousVersion })
+
+	// Act
+	version, err := utils.ApplicationVersion()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, version)
+}
@@ -0,0 +1,104 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matzefriedrich/parsley/internal/watch"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Watcher_polling_reports_changed_source_files_but_not_generated_files(t *testing.T) {
+
+	// Arrange
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "unchanged.go"), []byte("package main\n"), 0644))
+
+	sut, err := watch.NewWatcher(dir, func(config *watch.WatcherOptions) {
+		config.ForcePolling = true
+		config.PollInterval = 10 * time.Millisecond
+	})
+	assert.NoError(t, err)
+
+	// Act
+	changes := watchChanges(t, sut, func() {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "greeter.mocks.g.go"), []byte("package main\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "greeter.go"), []byte("package main\n"), 0644))
+	})
+
+	// Assert
+	assert.Equal(t, []string{filepath.Join(dir, "greeter.go")}, changes)
+}
+
+func Test_Watcher_reports_files_written_to_new_subdirectories(t *testing.T) {
+
+	// Arrange
+	dir := t.TempDir()
+
+	sut, err := watch.NewWatcher(dir, func(config *watch.WatcherOptions) {
+		config.PollInterval = 10 * time.Millisecond
+	})
+	assert.NoError(t, err)
+
+	// Act
+	changes := watchChanges(t, sut, func() {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "services"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "services", "greeter.go"), []byte("package services\n"), 0644))
+	})
+
+	// Assert
+	assert.Equal(t, []string{filepath.Join(dir, "services", "greeter.go")}, changes)
+}
+
+func Test_GoFiles_skips_generated_files_and_ignored_directories(t *testing.T) {
+
+	// Arrange
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "main.mocks.g.go", "README.md", filepath.Join("testdata", "fixture.go"), filepath.Join("internal", "service.go")} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("package main\n"), 0644))
+	}
+
+	// Act
+	actual, err := watch.GoFiles(dir)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "internal", "service.go"), filepath.Join(dir, "main.go")}, actual)
+}
+
+// watchChanges runs the watcher, applies the given changes, and returns the paths of the first reported change.
+func watchChanges(t *testing.T, sut watch.Watcher, change func()) []string {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan []string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- sut.Watch(ctx, func(paths []string) {
+			select {
+			case changes <- paths:
+			default:
+			}
+		})
+	}()
+
+	// Give the polling watcher the chance to take its initial snapshot
+	time.Sleep(50 * time.Millisecond)
+	change()
+
+	var actual []string
+	select {
+	case actual = <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+	return actual
+}
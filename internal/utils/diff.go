@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 3
+
+type diffOperation struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff compares the lines of a and b and returns the differences in unified diff format, or an empty string if both are equal. The given names are used for the file headers.
+func UnifiedDiff(fromName string, toName string, a []byte, b []byte) string {
+
+	operations := diffLines(splitLines(a), splitLines(b))
+
+	buffer := strings.Builder{}
+	index := 0
+	for index < len(operations) {
+
+		for index < len(operations) && operations[index].kind == ' ' {
+			index++
+		}
+		if index == len(operations) {
+			break
+		}
+
+		if buffer.Len() == 0 {
+			_, _ = fmt.Fprintf(&buffer, "--- %s\n+++ %s\n", fromName, toName)
+		}
+
+		start := max(index-diffContextLines, 0)
+		end := index
+		for {
+			for end < len(operations) && operations[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(operations) && operations[next].kind == ' ' {
+				next++
+			}
+			if next < len(operations) && next-end <= 2*diffContextLines {
+				end = next
+				continue
+			}
+			end = min(end+diffContextLines, len(operations))
+			break
+		}
+
+		writeHunk(&buffer, operations, start, end)
+		index = end
+	}
+
+	return buffer.String()
+}
+
+func writeHunk(buffer *strings.Builder, operations []diffOperation, start int, end int) {
+
+	fromLine, toLine := 1, 1
+	for _, operation := range operations[:start] {
+		if operation.kind != '+' {
+			fromLine++
+		}
+		if operation.kind != '-' {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+	for _, operation := range operations[start:end] {
+		if operation.kind != '+' {
+			fromCount++
+		}
+		if operation.kind != '-' {
+			toCount++
+		}
+	}
+
+	// An empty range refers to the line before the hunk
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+
+	_, _ = fmt.Fprintf(buffer, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+	for _, operation := range operations[start:end] {
+		buffer.WriteByte(operation.kind)
+		buffer.WriteString(operation.line)
+		buffer.WriteByte('\n')
+	}
+}
+
+// diffLines computes a minimal edit script that transforms a into b, based on the longest common subsequence of both.
+func diffLines(a []string, b []string) []diffOperation {
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	operations := make([]diffOperation, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		operations = append(operations, diffOperation{kind: ' ', line: line})
+	}
+
+	from, to := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(from), len(to)
+
+	// lcs[i][j] holds the length of the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case from[i] == to[j]:
+			operations = append(operations, diffOperation{kind: ' ', line: from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			operations = append(operations, diffOperation{kind: '-', line: from[i]})
+			i++
+		default:
+			operations = append(operations, diffOperation{kind: '+', line: to[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		operations = append(operations, diffOperation{kind: '-', line: from[i]})
+	}
+	for ; j < m; j++ {
+		operations = append(operations, diffOperation{kind: '+', line: to[j]})
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		operations = append(operations, diffOperation{kind: ' ', line: line})
+	}
+
+	return operations
+}
+
+func splitLines(text []byte) []string {
+	if len(text) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+}
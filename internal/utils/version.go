@@ -4,11 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"runtime/debug"
 	"strconv"
+
+	"golang.org/x/mod/module"
 )
 
 var (
-	VersionString string = "1.4.2"
+	// VersionString is the version of the application; it is set via linker flags by release builds. If it is not set, the version of the main module
+	// recorded in the build information is used instead, for instance, the version of a binary installed with go install.
+	VersionString string
 )
 
 // VersionInfo represents the version details using semantic versioning.
@@ -66,15 +71,32 @@ func (v VersionInfo) Compare(other VersionInfo) ComparisonResult {
 	return GreaterThan
 }
 
-// ApplicationVersion parses and returns the application's version information. If the version is not set, an error is returned.
+// ApplicationVersion parses and returns the application's version information. If the version is not set, or if the application is a development build, an error is returned.
 func ApplicationVersion() (*VersionInfo, error) {
-	v, err := tryParseVersionInfo(VersionString)
+	v, err := tryParseVersionInfo(applicationVersionString())
 	if err != nil {
 		return nil, errors.New("application version not set")
 	}
 	return v, nil
 }
 
+// applicationVersionString returns VersionString, or the version of the main module recorded in the build information if VersionString is not set.
+// Returns an empty string for development builds, which are recorded as (devel), and for pseudo-versions that are not based on a release.
+func applicationVersionString() string {
+	if VersionString != "" {
+		return VersionString
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	version := info.Main.Version
+	if module.IsPseudoVersion(version) {
+		version, _ = module.PseudoVersionBase(version)
+	}
+	return version
+}
+
 func isLessThan(v VersionInfo, other VersionInfo) bool {
 	return v.Major < other.Major ||
 		(v.Major == other.Major && v.Minor < other.Minor) ||
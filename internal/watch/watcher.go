@@ -0,0 +1,152 @@
+// Package watch detects changes of Go source files below a directory, either by polling the file system or, on Linux, by using inotify.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Watcher notifies about Go source files that have been created or modified below a directory. Generated files (*.g.go) are not reported.
+type Watcher interface {
+	// Watch blocks until the context is canceled and invokes the callback with the paths of the files changed since the previous invocation.
+	Watch(ctx context.Context, changed func(paths []string)) error
+}
+
+// WatcherOptions holds the options of a Watcher.
+type WatcherOptions struct {
+	// PollInterval defines how often the file system is checked for changes if polling is used; also used to batch change notifications.
+	PollInterval time.Duration
+	// ForcePolling disables platform-specific change notifications, for instance, inotify on Linux.
+	ForcePolling bool
+}
+
+// WatcherOptionsFunc configures WatcherOptions.
+type WatcherOptionsFunc func(config *WatcherOptions)
+
+const defaultPollInterval = 500 * time.Millisecond
+
+// NewWatcher creates a Watcher for the given directory and its subdirectories. On Linux, inotify is used unless polling is forced, or inotify is not available; on other platforms, the file system is polled.
+func NewWatcher(dir string, config ...WatcherOptionsFunc) (Watcher, error) {
+	options := WatcherOptions{
+		PollInterval: defaultPollInterval,
+	}
+	for _, f := range config {
+		f(&options)
+	}
+	if !options.ForcePolling {
+		if w, err := newPlatformWatcher(dir, options); err == nil {
+			return w, nil
+		}
+	}
+	return newPollingWatcher(dir, options)
+}
+
+// GoFiles returns the paths of the Go source files below the given directory that are observed by a Watcher, ordered by path.
+func GoFiles(dir string) ([]string, error) {
+	states, err := snapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(states))
+	for path := range states {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// isWatchedFile checks whether changes of the given file are reported; generated files are excluded, so that writing generator output does not trigger another change.
+func isWatchedFile(name string) bool {
+	return filepath.Ext(name) == ".go" && !strings.HasSuffix(name, ".g.go")
+}
+
+// isSkippedDirectory checks whether the given directory is excluded from watching, like the go tool ignores vendor, testdata, and hidden directories.
+func isSkippedDirectory(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func snapshot(dir string) (map[string]fileState, error) {
+	states := make(map[string]fileState)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != dir && isSkippedDirectory(entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isWatchedFile(entry.Name()) {
+			return nil
+		}
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			// The file has been removed since the directory has been read
+			return nil
+		}
+		states[filePath] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return states, err
+}
+
+type pollingWatcher struct {
+	dir      string
+	interval time.Duration
+}
+
+func newPollingWatcher(dir string, options WatcherOptions) (Watcher, error) {
+	return &pollingWatcher{
+		dir:      dir,
+		interval: options.PollInterval,
+	}, nil
+}
+
+// Watch compares snapshots of the modification times and sizes of the watched files in the configured interval.
+func (w *pollingWatcher) Watch(ctx context.Context, changed func(paths []string)) error {
+
+	previous, err := snapshot(w.dir)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshot(w.dir)
+		if err != nil {
+			// Directories can be renamed or removed while they are scanned; retry with the next tick
+			continue
+		}
+
+		paths := make([]string, 0)
+		for path, state := range current {
+			if previousState, found := previous[path]; !found || previousState != state {
+				paths = append(paths, path)
+			}
+		}
+		previous = current
+
+		if len(paths) > 0 {
+			slices.Sort(paths)
+			changed(paths)
+		}
+	}
+}
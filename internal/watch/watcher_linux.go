@@ -0,0 +1,168 @@
+//go:build linux
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// inotifyMask selects the events that indicate a completely written file, or a new directory; IN_CREATE alone is not reported for files, since the content may still be written.
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE | syscall.IN_DELETE_SELF
+
+type inotifyWatcher struct {
+	fd       int
+	file     *os.File
+	watches  map[int32]string
+	debounce time.Duration
+}
+
+func newPlatformWatcher(dir string, options WatcherOptions) (Watcher, error) {
+
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	w := &inotifyWatcher{
+		fd: fd,
+		// A non-blocking descriptor is registered with the runtime poller, so that closing the file interrupts pending reads; calling Fd would switch it back to blocking mode
+		file:     os.NewFile(uintptr(fd), "inotify"),
+		watches:  make(map[int32]string),
+		debounce: options.PollInterval,
+	}
+
+	_, err = w.addDirectories(dir)
+	if err != nil {
+		_ = w.file.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// addDirectories watches the given directory and its subdirectories, and returns the watched files that already exist.
+func (w *inotifyWatcher) addDirectories(dir string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			if isWatchedFile(entry.Name()) {
+				files = append(files, filePath)
+			}
+			return nil
+		}
+		if filePath != dir && isSkippedDirectory(entry.Name()) {
+			return filepath.SkipDir
+		}
+		wd, addErr := syscall.InotifyAddWatch(w.fd, filePath, inotifyMask)
+		if addErr != nil {
+			return os.NewSyscallError("inotify_add_watch", addErr)
+		}
+		w.watches[int32(wd)] = filePath
+		return nil
+	})
+	return files, err
+}
+
+// Watch reads inotify events and reports the changed files once no further event has been received for the configured interval, since editors often write a file in several steps.
+func (w *inotifyWatcher) Watch(ctx context.Context, changed func(paths []string)) error {
+
+	events := make(chan string)
+	var readErr error
+	go func() {
+		defer close(events)
+		readErr = w.readEvents(events)
+	}()
+
+	pending := make(map[string]struct{})
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			_ = w.file.Close()
+			for range events {
+			}
+			return nil
+		case path, ok := <-events:
+			if !ok {
+				return readErr
+			}
+			pending[path] = struct{}{}
+			flush = time.After(w.debounce)
+		case <-flush:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			slices.Sort(paths)
+			clear(pending)
+			flush = nil
+			changed(paths)
+		}
+	}
+}
+
+// inotifyEventHeaderSize is the size of the fixed part of an inotify_event: wd, mask, cookie, and len.
+const inotifyEventHeaderSize = syscall.SizeofInotifyEvent
+
+func (w *inotifyWatcher) readEvents(events chan<- string) error {
+
+	buffer := make([]byte, 64*(inotifyEventHeaderSize+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buffer)
+		if err != nil {
+			if errors.Is(err, os.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		for offset := 0; offset+inotifyEventHeaderSize <= n; {
+			wd := int32(binary.NativeEndian.Uint32(buffer[offset:]))
+			mask := binary.NativeEndian.Uint32(buffer[offset+4:])
+			nameLength := int(binary.NativeEndian.Uint32(buffer[offset+12:]))
+			name := string(bytes.TrimRight(buffer[offset+inotifyEventHeaderSize:offset+inotifyEventHeaderSize+nameLength], "\x00"))
+			offset += inotifyEventHeaderSize + nameLength
+
+			dir, found := w.watches[wd]
+			if !found {
+				continue
+			}
+			if mask&syscall.IN_DELETE_SELF != 0 || mask&syscall.IN_IGNORED != 0 {
+				delete(w.watches, wd)
+				continue
+			}
+
+			filePath := filepath.Join(dir, name)
+			if mask&syscall.IN_ISDIR != 0 {
+				if mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) == 0 || isSkippedDirectory(name) {
+					continue
+				}
+				// Files can be written before the watch of a new directory has been added
+				files, addErr := w.addDirectories(filePath)
+				if addErr != nil {
+					continue
+				}
+				for _, file := range files {
+					events <- file
+				}
+				continue
+			}
+
+			if mask&(syscall.IN_CLOSE_WRITE|syscall.IN_MOVED_TO) != 0 && isWatchedFile(name) {
+				events <- filePath
+			}
+		}
+	}
+}
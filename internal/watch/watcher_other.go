@@ -0,0 +1,9 @@
+//go:build !linux
+
+package watch
+
+import "errors"
+
+func newPlatformWatcher(_ string, _ WatcherOptions) (Watcher, error) {
+	return nil, errors.New("file system notifications are not supported on this platform")
+}
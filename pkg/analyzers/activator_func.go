@@ -0,0 +1,124 @@
+package analyzers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// ActivatorFuncAnalyzer reports arguments of registration functions that are not valid activator functions.
+var ActivatorFuncAnalyzer = &analysis.Analyzer{
+	Name: "parsleyactivator",
+	Doc: `report values registered with Parsley that are not valid activator functions
+
+Register, RegisterSingleton, RegisterScoped, RegisterTransient, RegisterLazy, NamedServiceRegistration, and Activate
+expect activator functions that return a service of a pointer, interface, function, or struct type, or a slice of
+such services, and optionally an error. Other values are rejected at runtime. If an activator function is called instead of being passed, the
+suggested fix passes the function.`,
+	URL:      "https://pkg.go.dev/github.com/matzefriedrich/parsley/pkg/analyzers#ActivatorFuncAnalyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runActivatorFuncAnalyzer,
+}
+
+func runActivatorFuncAnalyzer(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	for c := range in.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := c.Node().(*ast.CallExpr)
+		for _, argument := range activatorArguments(pass.TypesInfo, call) {
+			checkActivatorArgument(pass, argument)
+		}
+	}
+	return nil, nil
+}
+
+func checkActivatorArgument(pass *analysis.Pass, argument ast.Expr) {
+
+	t := pass.TypesInfo.TypeOf(argument)
+	if t == nil {
+		return
+	}
+
+	if types.Identical(t, types.Typ[types.UntypedNil]) {
+		pass.ReportRangef(argument, "nil is not an activator function")
+		return
+	}
+
+	signature, isFunc := t.Underlying().(*types.Signature)
+	if isFunc {
+		if problem := activatorSignatureProblem(signature, types.RelativeTo(pass.Pkg)); problem != "" {
+			pass.ReportRangef(argument, "%s is not a valid activator function: %s", textOf(pass, argument), problem)
+		}
+		return
+	}
+
+	// The activator function has been called instead of being passed; Parsley resolves its parameters and invokes it.
+	if function, isCalled := calledActivatorFunc(pass, argument); isCalled {
+		pass.Report(analysis.Diagnostic{
+			Pos:     argument.Pos(),
+			End:     argument.End(),
+			Message: fmt.Sprintf("%s is not an activator function; pass the activator function %s instead of calling it", textOf(pass, argument), function),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Pass %s as the activator function", function),
+				TextEdits: []analysis.TextEdit{{Pos: argument.Pos(), End: argument.End(), NewText: []byte(function)}},
+			}},
+		})
+		return
+	}
+
+	// The dynamic type of interface values, and of values of a type parameter, is unknown
+	if _, isInterface := t.Underlying().(*types.Interface); isInterface {
+		return
+	}
+
+	message := fmt.Sprintf("%s is not an activator function", textOf(pass, argument))
+	if isSupportedServiceType(t) {
+		typeName := types.TypeString(t, types.RelativeTo(pass.Pkg))
+		message += fmt.Sprintf("; pass a function that returns the %s service, or use registration.RegisterInstance to register an existing value", typeName)
+	}
+	pass.ReportRangef(argument, "%s", message)
+}
+
+// calledActivatorFunc checks whether the given argument is a call of a function that is a valid activator function, and returns the source code of the called function.
+func calledActivatorFunc(pass *analysis.Pass, argument ast.Expr) (string, bool) {
+	call, ok := ast.Unparen(argument).(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	signature, isFunc := pass.TypesInfo.TypeOf(call.Fun).(*types.Signature)
+	if !isFunc || activatorSignatureProblem(signature, types.RelativeTo(pass.Pkg)) != "" {
+		return "", false
+	}
+	// Functions returning any are typically accessors of activator functions, for instance, NamedService.ActivatorFunc
+	if result, isInterface := signature.Results().At(0).Type().Underlying().(*types.Interface); isInterface && result.Empty() {
+		return "", false
+	}
+	return textOf(pass, call.Fun), true
+}
+
+// activatorSignatureProblem describes why a function of the given signature cannot be used as an activator function, or returns an empty string if it can.
+func activatorSignatureProblem(signature *types.Signature, qualifier types.Qualifier) string {
+	results := signature.Results()
+	switch {
+	case results.Len() == 0:
+		return "the function does not return a service"
+	case results.Len() > 2:
+		return "the function must return a service and optionally an error"
+	case results.Len() == 2 && !isErrorType(results.At(1).Type()):
+		return "the second return value must be an error"
+	}
+	serviceType := results.At(0).Type()
+	if slice, isSlice := serviceType.Underlying().(*types.Slice); isSlice {
+		serviceType = slice.Elem()
+	}
+	if _, isTypeParam := serviceType.(*types.TypeParam); isTypeParam {
+		return ""
+	}
+	if !isSupportedServiceType(serviceType) {
+		return fmt.Sprintf("the service type %s must be a pointer, interface, function, or struct type", types.TypeString(serviceType, qualifier))
+	}
+	return ""
+}
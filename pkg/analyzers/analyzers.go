@@ -0,0 +1,141 @@
+// Package analyzers provides go/analysis analyzers that report common misuse of the Parsley API at compile time, which otherwise only fails at runtime.
+// The analyzers can be run with the parsley-cli vet command, with go vet -vettool, or be integrated into gopls or golangci-lint.
+package analyzers
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	parsleyModulePath       = "github.com/matzefriedrich/parsley"
+	featuresPackagePath     = parsleyModulePath + "/pkg/features"
+	registrationPackagePath = parsleyModulePath + "/pkg/registration"
+	resolvingPackagePath    = parsleyModulePath + "/pkg/resolving"
+	typesPackagePath        = parsleyModulePath + "/pkg/types"
+)
+
+// Analyzers returns all Parsley analyzers.
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		ActivatorFuncAnalyzer,
+		ContextParameterAnalyzer,
+		RegistrationErrorAnalyzer,
+		ServiceTypeAnalyzer,
+	}
+}
+
+// calleeOf returns the function or method called by the given call expression, or nil if the callee is not statically known. For generic functions, the generic declaration is returned.
+func calleeOf(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, _ := typeutil.Callee(info, call).(*types.Func)
+	if fn == nil {
+		return nil
+	}
+	return fn.Origin()
+}
+
+// isPackageFunc checks whether fn is one of the named package-level functions of the specified package.
+func isPackageFunc(fn *types.Func, packagePath string, names ...string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != packagePath || fn.Signature().Recv() != nil {
+		return false
+	}
+	return slices.Contains(names, fn.Name())
+}
+
+// isRegistryMethod checks whether fn is one of the named methods of a registry type declared by Parsley, for instance, types.ServiceRegistry.
+func isRegistryMethod(fn *types.Func, names ...string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Signature().Recv() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case typesPackagePath, registrationPackagePath:
+		return slices.Contains(names, fn.Name())
+	default:
+		return false
+	}
+}
+
+// activatorArguments returns the arguments of the given call that Parsley treats as activator functions.
+func activatorArguments(info *types.Info, call *ast.CallExpr) []ast.Expr {
+	fn := calleeOf(info, call)
+	switch {
+	case isRegistryMethod(fn, "Register"):
+		return argumentsAt(call, 0)
+	case isPackageFunc(fn, registrationPackagePath, "RegisterTransient", "RegisterScoped", "RegisterSingleton"):
+		if call.Ellipsis.IsValid() || len(call.Args) < 2 {
+			return nil
+		}
+		return call.Args[1:]
+	case isPackageFunc(fn, featuresPackagePath, "RegisterLazy"),
+		isPackageFunc(fn, registrationPackagePath, "NamedServiceRegistration"):
+		return argumentsAt(call, 1)
+	case isPackageFunc(fn, resolvingPackagePath, "Activate"):
+		return argumentsAt(call, 2)
+	default:
+		return nil
+	}
+}
+
+func argumentsAt(call *ast.CallExpr, index int) []ast.Expr {
+	if len(call.Args) <= index {
+		return nil
+	}
+	return call.Args[index : index+1]
+}
+
+// isContextType checks whether t is context.Context.
+func isContextType(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context"
+}
+
+// isErrorType checks whether t is the predeclared error type.
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isSupportedServiceType checks whether t is of a kind that Parsley can register and resolve as a service.
+func isSupportedServiceType(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Signature, *types.Struct:
+		return true
+	default:
+		return false
+	}
+}
+
+// qualifiedName returns the name of fn as it is referred to in source code, for instance, registration.RegisterSingleton, or ServiceRegistry.Register.
+func qualifiedName(fn *types.Func) string {
+	if recv := fn.Signature().Recv(); recv != nil {
+		t := recv.Type()
+		if pointer, ok := t.(*types.Pointer); ok {
+			t = pointer.Elem()
+		}
+		if named, ok := types.Unalias(t).(*types.Named); ok {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+		return fn.Name()
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+// textOf returns the source code of the given node.
+func textOf(pass *analysis.Pass, node ast.Node) string {
+	file := pass.Fset.File(node.Pos())
+	if file == nil {
+		return ""
+	}
+	content, err := pass.ReadFile(file.Name())
+	if err != nil {
+		return ""
+	}
+	return string(content[file.Offset(node.Pos()):file.Offset(node.End())])
+}
@@ -0,0 +1,107 @@
+package analyzers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// ContextParameterAnalyzer reports activator functions that accept a context.Context parameter at a position other than the first.
+var ContextParameterAnalyzer = &analysis.Analyzer{
+	Name: "parsleycontext",
+	Doc: `report activator functions that do not accept a context.Context as their first parameter
+
+Parsley passes the resolver context only to the first parameter of an activator function. A context.Context
+parameter at any other position is treated as a service dependency that cannot be resolved. For function
+literals, the suggested fix moves the context parameter to the first position.`,
+	URL:      "https://pkg.go.dev/github.com/matzefriedrich/parsley/pkg/analyzers#ContextParameterAnalyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runContextParameterAnalyzer,
+}
+
+func runContextParameterAnalyzer(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	for c := range in.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := c.Node().(*ast.CallExpr)
+		for _, argument := range activatorArguments(pass.TypesInfo, call) {
+			checkContextParameter(pass, argument)
+		}
+	}
+	return nil, nil
+}
+
+func checkContextParameter(pass *analysis.Pass, argument ast.Expr) {
+
+	t := pass.TypesInfo.TypeOf(argument)
+	if t == nil {
+		return
+	}
+
+	signature, isFunc := t.Underlying().(*types.Signature)
+	if !isFunc {
+		return
+	}
+
+	params := signature.Params()
+	position := -1
+	for i := 1; i < params.Len(); i++ {
+		if isContextType(params.At(i).Type()) {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:     argument.Pos(),
+		End:     argument.End(),
+		Message: fmt.Sprintf("context.Context is parameter %d of the activator function; Parsley passes the context only to the first parameter and tries to resolve any other context.Context parameter as a service", position+1),
+	}
+
+	if literal, ok := ast.Unparen(argument).(*ast.FuncLit); ok {
+		if edit, ok := moveContextParameterFirst(pass, literal.Type.Params); ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Move the context.Context parameter to the first position",
+				TextEdits: []analysis.TextEdit{edit},
+			}}
+		}
+	}
+
+	pass.Report(diagnostic)
+}
+
+// moveContextParameterFirst creates an edit that moves the context.Context field of the given parameter list to the first position. No edit is created if the context parameter shares its field with other parameters.
+func moveContextParameterFirst(pass *analysis.Pass, params *ast.FieldList) (analysis.TextEdit, bool) {
+
+	contextField := -1
+	for i, field := range params.List {
+		if isContextType(pass.TypesInfo.TypeOf(field.Type)) {
+			contextField = i
+			break
+		}
+	}
+	if contextField <= 0 || len(params.List[contextField].Names) > 1 {
+		return analysis.TextEdit{}, false
+	}
+
+	fields := make([]string, 0, len(params.List))
+	fields = append(fields, textOf(pass, params.List[contextField]))
+	for i, field := range params.List {
+		if i != contextField {
+			fields = append(fields, textOf(pass, field))
+		}
+	}
+
+	return analysis.TextEdit{
+		Pos:     params.Opening + 1,
+		End:     params.Closing,
+		NewText: []byte(strings.Join(fields, ", ")),
+	}, true
+}
@@ -0,0 +1,96 @@
+package analyzers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"iter"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// RegistrationErrorAnalyzer reports calls of Parsley registration functions whose error result is silently discarded.
+var RegistrationErrorAnalyzer = &analysis.Analyzer{
+	Name: "parsleyregistererror",
+	Doc: `report unchecked errors of Parsley registration functions
+
+Registration functions, for instance, RegisterSingleton, RegisterModule, or RegisterLazy, validate the registered
+activator functions and return an error if the registration failed. If the error is silently discarded, the
+failure only surfaces later, when the service cannot be resolved. Explicit assignments to the blank identifier
+are not reported. If the enclosing function returns just an error, the suggested fix returns the registration error.`,
+	URL:      "https://pkg.go.dev/github.com/matzefriedrich/parsley/pkg/analyzers#RegistrationErrorAnalyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runRegistrationErrorAnalyzer,
+}
+
+func runRegistrationErrorAnalyzer(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	for c := range in.Root().Preorder((*ast.ExprStmt)(nil)) {
+		statement := c.Node().(*ast.ExprStmt)
+		call, ok := ast.Unparen(statement.X).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		fn := calleeOf(pass.TypesInfo, call)
+		if !isRegistrationFunc(fn) {
+			continue
+		}
+
+		diagnostic := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: fmt.Sprintf("the error returned by %s is not checked", qualifiedName(fn)),
+		}
+
+		if returnsOnlyError(pass.TypesInfo, c.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))) {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Return the registration error",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     statement.Pos(),
+					End:     statement.End(),
+					NewText: []byte("if err := " + textOf(pass, call) + "; err != nil {\n\treturn err\n}"),
+				}},
+			}}
+		}
+
+		pass.Report(diagnostic)
+	}
+	return nil, nil
+}
+
+// isRegistrationFunc checks whether fn is a registration function of the Parsley API that reports failures as an error.
+func isRegistrationFunc(fn *types.Func) bool {
+	if fn == nil || fn.Pkg() == nil || !strings.HasPrefix(fn.Pkg().Path(), parsleyModulePath+"/pkg/") {
+		return false
+	}
+	if !strings.HasPrefix(fn.Name(), "Register") {
+		return false
+	}
+	results := fn.Signature().Results()
+	return results.Len() == 1 && isErrorType(results.At(0).Type())
+}
+
+// returnsOnlyError checks whether the first function enclosing the cursor returns an error as its only result.
+func returnsOnlyError(info *types.Info, enclosing iter.Seq[inspector.Cursor]) bool {
+	for c := range enclosing {
+		var signature *types.Signature
+		switch node := c.Node().(type) {
+		case *ast.FuncDecl:
+			if fn, ok := info.Defs[node.Name].(*types.Func); ok {
+				signature = fn.Signature()
+			}
+		case *ast.FuncLit:
+			signature, _ = info.TypeOf(node).(*types.Signature)
+		}
+		if signature == nil {
+			return false
+		}
+		results := signature.Results()
+		return results.Len() == 1 && isErrorType(results.At(0).Type())
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package analyzers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// ServiceTypeAnalyzer reports generic Parsley functions instantiated with a type argument that is not a supported service type.
+var ServiceTypeAnalyzer = &analysis.Analyzer{
+	Name: "parsleyservicetype",
+	Doc: `report unsupported service types passed as type arguments to Parsley functions
+
+Services must be of a pointer, interface, function, or struct type; resolving functions and MakeServiceType also
+accept slices. Functions like ResolveRequiredService[T], RegisterList[T], or MakeServiceType[T] fail, or panic,
+at runtime if T is of any other kind. If the type argument is a named type, the suggested fix uses a pointer to it.`,
+	URL:      "https://pkg.go.dev/github.com/matzefriedrich/parsley/pkg/analyzers#ServiceTypeAnalyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runServiceTypeAnalyzer,
+}
+
+func runServiceTypeAnalyzer(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	for c := range in.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := c.Node().(*ast.CallExpr)
+
+		fn := calleeOf(pass.TypesInfo, call)
+		acceptsSlices := false
+		switch {
+		case isPackageFunc(fn, resolvingPackagePath, "ResolveRequiredService", "ResolveRequiredServices"),
+			isPackageFunc(fn, typesPackagePath, "MakeServiceType"):
+			acceptsSlices = true
+		case isPackageFunc(fn, featuresPackagePath, "RegisterList", "RegisterNamed", "RegisterLazy", "RegisterFactory"),
+			isPackageFunc(fn, registrationPackagePath, "RegisterInstance", "CreateServiceActivatorFrom"):
+		default:
+			continue
+		}
+
+		typeArgument := firstTypeArgument(pass.TypesInfo, call.Fun)
+		if typeArgument == nil {
+			continue
+		}
+		if _, isTypeParam := typeArgument.(*types.TypeParam); isTypeParam {
+			continue
+		}
+		if _, isSlice := typeArgument.Underlying().(*types.Slice); isSlice && acceptsSlices {
+			continue
+		}
+		if isSupportedServiceType(typeArgument) {
+			continue
+		}
+
+		typeName := types.TypeString(typeArgument, types.RelativeTo(pass.Pkg))
+		diagnostic := analysis.Diagnostic{
+			Pos:     call.Fun.Pos(),
+			End:     call.Fun.End(),
+			Message: fmt.Sprintf("%s is not a supported service type of %s; services must be of a pointer, interface, function, or struct type", typeName, qualifiedName(fn)),
+		}
+
+		// Suggest a pointer type if the type argument is spelled out and refers to a named type
+		if index, ok := ast.Unparen(call.Fun).(*ast.IndexExpr); ok {
+			if _, isNamed := types.Unalias(typeArgument).(*types.Named); isNamed {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+					Message:   fmt.Sprintf("Use *%s as the service type", typeName),
+					TextEdits: []analysis.TextEdit{{Pos: index.Index.Pos(), End: index.Index.Pos(), NewText: []byte("*")}},
+				}}
+			}
+		}
+
+		pass.Report(diagnostic)
+	}
+	return nil, nil
+}
+
+// firstTypeArgument returns the first type argument of the generic function referred to by the given expression, or nil if the function is not instantiated.
+func firstTypeArgument(info *types.Info, fun ast.Expr) types.Type {
+	fun = ast.Unparen(fun)
+	switch expr := fun.(type) {
+	case *ast.IndexExpr:
+		fun = expr.X
+	case *ast.IndexListExpr:
+		fun = expr.X
+	}
+
+	var ident *ast.Ident
+	switch expr := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		ident = expr
+	case *ast.SelectorExpr:
+		ident = expr.Sel
+	default:
+		return nil
+	}
+
+	instance, ok := info.Instances[ident]
+	if !ok || instance.TypeArgs.Len() == 0 {
+		return nil
+	}
+	return instance.TypeArgs.At(0)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 
-	"github.com/matzefriedrich/parsley/internal/core"
 	"github.com/matzefriedrich/parsley/pkg/registration"
 	"github.com/matzefriedrich/parsley/pkg/resolving"
 	"github.com/matzefriedrich/parsley/pkg/types"
@@ -26,36 +25,30 @@ var (
 )
 
 // RunParsleyApplication initializes and runs the Parsley application lifecycle.
-// It registers the application factory, configures additional modules, resolves the main application instance, and invokes its Run method.
+// It registers the application factory, configures additional modules, resolves the main application instance and the registered hosted services,
+// starts the hosted services, and invokes the Run method of the application. If hosted services are registered, the context passed to Run is canceled
+// on SIGINT or SIGTERM, and the host waits until the context is canceled after Run has returned successfully; then, the hosted services
+// are stopped in reverse order within the shutdown timeout configured by WithShutdownTimeout. The errors of Run and of stopping the hosted services are joined.
+// Errors returned by modules are ignored, and the registry is not validated; use NewApplicationBuilder to fail early on misconfigurations.
 func RunParsleyApplication(cxt context.Context, appFactoryFunc any, configure ...types.ModuleFunc) error {
 
 	registry := registration.NewServiceRegistry()
 	registerErr := registry.Register(appFactoryFunc, types.LifetimeSingleton)
 	if registerErr != nil {
-		bootstrapErr := &types.ParsleyError{Msg: ErrorCannotRegisterAppFactory}
-		types.WithCause(registerErr)(bootstrapErr)
-		return bootstrapErr
+		return newBootstrapError(ErrorCannotRegisterAppFactory, types.WithCause(registerErr))
 	}
 	for _, m := range configure {
 		_ = m(registry)
 	}
 
-	resolver := resolving.NewResolver(registry)
-	ctx := resolving.NewScopedContext(cxt)
-	app, appErr := resolving.ResolveRequiredService[Application](ctx, resolver)
-	if appErr != nil {
-		activationErr := &types.ParsleyError{Msg: "failed to activate application"}
-		types.WithCause(appErr)(activationErr)
-		return activationErr
-	}
+	host := newHost(registry, resolving.NewResolver(registry), nil)
+	return host.Run(cxt)
+}
 
-	parsley := infrastructure{
-		registry: registry,
-		resolver: resolver,
-		app:      app,
+func newBootstrapError(msg string, initializers ...types.ParsleyErrorFunc) error {
+	err := &types.ParsleyError{Msg: msg}
+	for _, initializer := range initializers {
+		initializer(err)
 	}
-
-	appContext := context.WithValue(ctx, core.ContextKey("__parsley-infrastructure"), parsley)
-
-	return app.Run(appContext)
+	return err
 }
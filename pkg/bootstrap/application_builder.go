@@ -0,0 +1,113 @@
+package bootstrap
+
+import (
+	"errors"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const (
+	ErrorCannotConfigureModules = "cannot configure modules"
+	ErrorInvalidRegistry        = "the service registry is invalid"
+)
+
+var (
+	// ErrCannotConfigureModules is returned by ApplicationBuilder.Build if modules return errors; the error aggregates the errors of all modules.
+	ErrCannotConfigureModules = errors.New(ErrorCannotConfigureModules)
+	// ErrInvalidRegistry is returned by ApplicationBuilder.Build if strict validation is enabled and the validation of the service registry fails.
+	ErrInvalidRegistry = errors.New(ErrorInvalidRegistry)
+)
+
+// ValidationMode specifies how the ApplicationBuilder validates the service registry.
+type ValidationMode int
+
+const (
+	// ValidationDisabled skips the validation of the service registry.
+	ValidationDisabled ValidationMode = iota
+	// ValidationLenient validates the service registry and reports validation errors to the observers as an EventRegistryValidated event, without failing.
+	ValidationLenient
+	// ValidationStrict validates the service registry and fails if any issues are found.
+	ValidationStrict
+)
+
+// ApplicationBuilder configures and builds a Host that runs an Application. Unlike RunParsleyApplication, the builder propagates errors returned
+// by modules, and can validate the service registry, so that misconfigurations are detected before the application is run.
+type ApplicationBuilder struct {
+	appFactoryFunc any
+	modules        []types.ModuleFunc
+	validation     ValidationMode
+	validator      registration.Validator
+	observers      []LifecycleObserverFunc
+}
+
+// NewApplicationBuilder creates a new ApplicationBuilder for the application created by the given activator function.
+func NewApplicationBuilder(appFactoryFunc any) *ApplicationBuilder {
+	return &ApplicationBuilder{
+		appFactoryFunc: appFactoryFunc,
+		modules:        make([]types.ModuleFunc, 0),
+		validation:     ValidationDisabled,
+		validator:      registration.NewServiceRegistrationsValidator(),
+		observers:      make([]LifecycleObserverFunc, 0),
+	}
+}
+
+// WithModules adds modules that register the services of the application.
+func (b *ApplicationBuilder) WithModules(modules ...types.ModuleFunc) *ApplicationBuilder {
+	b.modules = append(b.modules, modules...)
+	return b
+}
+
+// WithValidation sets the mode of the validation of the service registry; the validation is disabled by default.
+func (b *ApplicationBuilder) WithValidation(mode ValidationMode) *ApplicationBuilder {
+	b.validation = mode
+	return b
+}
+
+// WithValidator replaces the validator used to validate the service registry.
+func (b *ApplicationBuilder) WithValidator(validator registration.Validator) *ApplicationBuilder {
+	b.validator = validator
+	return b
+}
+
+// WithObserver adds observers that get notified about the lifecycle events of the application.
+func (b *ApplicationBuilder) WithObserver(observers ...LifecycleObserverFunc) *ApplicationBuilder {
+	b.observers = append(b.observers, observers...)
+	return b
+}
+
+// Build registers the application factory, configures all modules, and validates the service registry according to the validation mode.
+// Returns an error if the application factory cannot be registered, if any module fails, or if the strict validation of the registry fails.
+func (b *ApplicationBuilder) Build() (*Host, error) {
+
+	registry := registration.NewServiceRegistry()
+	registerErr := registry.Register(b.appFactoryFunc, types.LifetimeSingleton)
+	if registerErr != nil {
+		return nil, newBootstrapError(ErrorCannotRegisterAppFactory, types.WithCause(registerErr))
+	}
+
+	moduleErrors := make([]error, 0)
+	for _, m := range b.modules {
+		if err := m(registry); err != nil {
+			moduleErrors = append(moduleErrors, err)
+		}
+	}
+	if len(moduleErrors) > 0 {
+		return nil, newBootstrapError(ErrorCannotConfigureModules, types.WithAggregatedCause(moduleErrors...))
+	}
+
+	// The resolver registers itself, so it must be created before the registry is validated
+	resolver := resolving.NewResolver(registry)
+	host := newHost(registry, resolver, b.observers)
+
+	if b.validation != ValidationDisabled {
+		validationErr := b.validator.Validate(registry)
+		host.notify(LifecycleEvent{Kind: EventRegistryValidated, Err: validationErr})
+		if validationErr != nil && b.validation == ValidationStrict {
+			return nil, newBootstrapError(ErrorInvalidRegistry, types.WithCause(validationErr))
+		}
+	}
+
+	return host, nil
+}
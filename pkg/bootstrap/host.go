@@ -0,0 +1,101 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// Host runs an Application and manages the lifecycle of its hosted services. Use NewApplicationBuilder to create a Host.
+type Host struct {
+	registry  types.ServiceRegistry
+	resolver  types.Resolver
+	observers []LifecycleObserverFunc
+}
+
+func newHost(registry types.ServiceRegistry, resolver types.Resolver, observers []LifecycleObserverFunc) *Host {
+	return &Host{
+		registry:  registry,
+		resolver:  resolver,
+		observers: observers,
+	}
+}
+
+// Registry returns the service registry of the application; for instance, to inspect registrations in tests.
+func (h *Host) Registry() types.ServiceRegistry {
+	return h.registry
+}
+
+// Resolver returns the resolver of the application; for instance, to resolve services in tests without running the application.
+func (h *Host) Resolver() types.Resolver {
+	return h.resolver
+}
+
+// Run resolves the application and the registered hosted services, starts the hosted services, and invokes the Run method of the application.
+// If hosted services are registered, the context passed to the application is canceled on SIGINT or SIGTERM, and the host waits until the context
+// is canceled after the application has returned successfully; then, the hosted services are stopped in reverse order within the shutdown timeout.
+// Applications without hosted services return from Run as soon as the application returns; the host leaves the handling of signals to the caller.
+func (h *Host) Run(cxt context.Context) error {
+
+	if h.registry.IsRegistered(types.MakeServiceType[HostedService]()) {
+		signalContext, stopSignals := signal.NotifyContext(cxt, os.Interrupt, syscall.SIGTERM)
+		defer stopSignals()
+		go func() {
+			<-signalContext.Done()
+			stopSignals() // restores the default behavior, so that a second signal terminates an application that does not shut down
+		}()
+		cxt = signalContext
+	}
+
+	ctx := resolving.NewScopedContext(cxt)
+	app, appErr := resolving.ResolveRequiredService[Application](ctx, h.resolver)
+	if appErr != nil {
+		return newBootstrapError("failed to activate application", types.WithCause(appErr))
+	}
+
+	hosted, hostedErr := resolveHostedServices(ctx, h.registry, h.resolver)
+	if hostedErr != nil {
+		return hostedErr
+	}
+	hosted.notify = h.notify
+
+	parsley := infrastructure{
+		registry: h.registry,
+		resolver: h.resolver,
+		app:      app,
+	}
+
+	appContext := context.WithValue(ctx, infrastructureContextKey, parsley)
+
+	if startErr := hosted.start(appContext); startErr != nil {
+		return startErr
+	}
+
+	h.notify(LifecycleEvent{Kind: EventApplicationStarted})
+	runErr := app.Run(appContext)
+	h.notify(LifecycleEvent{Kind: EventApplicationStopped, Err: runErr})
+
+	if runErr == nil && len(hosted.services) > 0 {
+		<-appContext.Done()
+	}
+
+	stopErr := hosted.stop(appContext)
+	switch {
+	case runErr == nil:
+		return stopErr
+	case stopErr == nil:
+		return runErr
+	}
+	return errors.Join(runErr, stopErr)
+}
+
+func (h *Host) notify(event LifecycleEvent) {
+	for _, observer := range h.observers {
+		observer(event)
+	}
+}
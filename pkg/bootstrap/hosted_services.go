@@ -0,0 +1,248 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/matzefriedrich/parsley/internal"
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// DefaultShutdownTimeout is the time hosted services are given to stop, unless configured otherwise.
+const DefaultShutdownTimeout = 30 * time.Second
+
+const (
+	ErrorCannotResolveHostedServices = "cannot resolve hosted services"
+	ErrorCannotStartHostedServices   = "failed to start hosted services"
+	ErrorCannotStopHostedServices    = "failed to stop hosted services"
+)
+
+var (
+	// ErrCannotResolveHostedServices is returned if the hosted services cannot be activated.
+	ErrCannotResolveHostedServices = errors.New(ErrorCannotResolveHostedServices)
+	// ErrCannotStartHostedServices is returned if a hosted service fails to start; the error aggregates the start error and the errors of stopping the services started before.
+	ErrCannotStartHostedServices = errors.New(ErrorCannotStartHostedServices)
+	// ErrCannotStopHostedServices is returned if hosted services fail to stop, or do not stop within the shutdown timeout; the error aggregates the errors of all services.
+	ErrCannotStopHostedServices = errors.New(ErrorCannotStopHostedServices)
+)
+
+// HostOptions configures the lifecycle of hosted services.
+type HostOptions struct {
+	// ShutdownTimeout is the time all hosted services are given to stop; defaults to DefaultShutdownTimeout. The services are stopped one after
+	// another and share this budget: if a service exhausts it, the remaining services are stopped with an expired context.
+	ShutdownTimeout time.Duration
+}
+
+// WithShutdownTimeout returns a module function that configures the time hosted services are given to stop. The timeout is shared by all
+// hosted services, not granted to each of them: services that are stopped after a slow service get the remaining time only, and an expired
+// context once the timeout has elapsed. A service that does not return from Stop in time is reported with context.DeadlineExceeded.
+func WithShutdownTimeout(timeout time.Duration) types.ModuleFunc {
+	return func(registry types.ServiceRegistry) error {
+		return registration.RegisterInstance(registry, HostOptions{ShutdownTimeout: timeout})
+	}
+}
+
+// RegisterHostedService registers the service T as a HostedService, so that the host starts and stops it with the application. The service T must be
+// registered separately, for instance, a features.OptionsMonitor that polls its sources only while the application is running.
+func RegisterHostedService[T HostedService](registry types.ServiceRegistry) error {
+	return registration.RegisterSingleton(registry, func(service T) HostedService {
+		return service
+	})
+}
+
+type hostedServices struct {
+	services []HostedService
+	options  HostOptions
+	notify   LifecycleObserverFunc
+}
+
+// resolveHostedServices resolves all registered hosted services and orders them by their dependencies.
+func resolveHostedServices(ctx context.Context, registry types.ServiceRegistry, resolver types.Resolver) (*hostedServices, error) {
+
+	hosted := &hostedServices{
+		services: make([]HostedService, 0),
+		options:  HostOptions{ShutdownTimeout: DefaultShutdownTimeout},
+		notify:   func(LifecycleEvent) {},
+	}
+
+	if registry.IsRegistered(types.MakeServiceType[HostOptions]()) {
+		options, err := resolving.ResolveRequiredService[HostOptions](ctx, resolver)
+		if err != nil {
+			return nil, newBootstrapError(ErrorCannotResolveHostedServices, types.WithCause(err))
+		}
+		if options.ShutdownTimeout > 0 {
+			hosted.options.ShutdownTimeout = options.ShutdownTimeout
+		}
+	}
+
+	serviceType := types.MakeServiceType[HostedService]()
+	if !registry.IsRegistered(serviceType) {
+		return hosted, nil
+	}
+
+	services, err := resolving.ResolveRequiredServices[HostedService](ctx, resolver)
+	if err != nil {
+		return nil, newBootstrapError(ErrorCannotResolveHostedServices, types.WithCause(err))
+	}
+
+	list, _ := registry.TryGetServiceRegistrations(serviceType)
+	hosted.services = orderByDependencies(registry, list.Registrations(), services)
+	return hosted, nil
+}
+
+// start starts the hosted services in order. If a service fails to start, the services started before are stopped in reverse order.
+func (h *hostedServices) start(ctx context.Context) error {
+	for i, service := range h.services {
+		err := service.Start(ctx)
+		h.notify(LifecycleEvent{Kind: EventHostedServiceStarted, Service: fmt.Sprintf("%T", service), Err: err})
+		if err == nil {
+			continue
+		}
+		errs := []error{fmt.Errorf("%T: %w", service, err)}
+		stopErr := h.stopServices(ctx, h.services[:i])
+		if stopErr != nil {
+			errs = append(errs, stopErr)
+		}
+		return newBootstrapError(ErrorCannotStartHostedServices, types.WithCause(errors.Join(errs...)))
+	}
+	return nil
+}
+
+// stop stops all hosted services in reverse order within the shutdown timeout.
+func (h *hostedServices) stop(ctx context.Context) error {
+	return h.stopServices(ctx, h.services)
+}
+
+func (h *hostedServices) stopServices(ctx context.Context, services []HostedService) error {
+
+	if len(services) == 0 {
+		return nil
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.options.ShutdownTimeout)
+	defer cancel()
+
+	errs := make([]error, 0)
+	for _, service := range slices.Backward(services) {
+		err := stopService(stopCtx, service)
+		h.notify(LifecycleEvent{Kind: EventHostedServiceStopped, Service: fmt.Sprintf("%T", service), Err: err})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", service, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return newBootstrapError(ErrorCannotStopHostedServices, types.WithCause(errors.Join(errs...)))
+	}
+	return nil
+}
+
+// stopService stops the given service, but does not wait for it beyond the deadline of the given context. The result of Stop is reported if
+// it is available when the deadline expires.
+func stopService(ctx context.Context, service HostedService) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- service.Stop(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		// select chooses randomly among ready cases, so check for the result of the service before reporting the deadline
+		select {
+		case err := <-done:
+			return err
+		default:
+			return ctx.Err()
+		}
+	}
+}
+
+// orderByDependencies orders the given hosted services, so that each service comes after the hosted services it depends on. Services that
+// do not depend on each other, or depend on each other circularly, keep the order of their registrations.
+func orderByDependencies(registry types.ServiceRegistry, registrations []types.ServiceRegistration, services []HostedService) []HostedService {
+
+	if len(registrations) != len(services) {
+		return services
+	}
+
+	dependencies := make([]map[reflect.Type]bool, len(services))
+	for i, r := range registrations {
+		dependencies[i] = requiredTypesOf(registry, r)
+	}
+
+	// A hosted service depends on another one if its dependency graph contains the type of the other service's instance,
+	// or a type required by the other service's registration that the instance is assignable to.
+	dependsOn := func(i, j int) bool {
+		instanceType := reflect.TypeOf(services[j])
+		if dependencies[i][instanceType] {
+			return true
+		}
+		for _, t := range registrations[j].RequiredServiceTypes() {
+			requiredType := t.ReflectedType()
+			if dependencies[i][requiredType] && instanceType.AssignableTo(requiredType) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ordered := make([]HostedService, 0, len(services))
+	done := make([]bool, len(services))
+	for len(ordered) < len(services) {
+		next := -1
+		for i := range services {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for j := range services {
+				if i != j && !done[j] && dependsOn(i, j) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			next = slices.Index(done, false) // circular dependency
+		}
+		done[next] = true
+		ordered = append(ordered, services[next])
+	}
+	return ordered
+}
+
+// requiredTypesOf returns all types the given registration depends on, directly or indirectly.
+func requiredTypesOf(registry types.ServiceRegistry, r types.ServiceRegistration) map[reflect.Type]bool {
+	required := make(map[reflect.Type]bool)
+	visited := make(map[uint64]struct{})
+	stack := internal.MakeStack[types.ServiceRegistration](r)
+	for stack.Any() {
+		next := stack.Pop()
+		if _, seen := visited[next.Id()]; seen {
+			continue
+		}
+		visited[next.Id()] = struct{}{}
+		for _, serviceType := range next.RequiredServiceTypes() {
+			required[serviceType.ReflectedType()] = true
+			list, found := registry.TryGetServiceRegistrations(serviceType)
+			if !found {
+				continue
+			}
+			for _, item := range list.Registrations() {
+				stack.Push(item)
+			}
+		}
+	}
+	return required
+}
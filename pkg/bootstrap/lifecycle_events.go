@@ -0,0 +1,28 @@
+package bootstrap
+
+// LifecycleEventKind identifies a lifecycle event of an application.
+type LifecycleEventKind string
+
+const (
+	// EventRegistryValidated is raised after the service registry has been validated; the event carries the validation error, if any.
+	EventRegistryValidated LifecycleEventKind = "registry-validated"
+	// EventHostedServiceStarted is raised after a hosted service has been started, or has failed to start.
+	EventHostedServiceStarted LifecycleEventKind = "hosted-service-started"
+	// EventHostedServiceStopped is raised after a hosted service has been stopped, or has failed to stop.
+	EventHostedServiceStopped LifecycleEventKind = "hosted-service-stopped"
+	// EventApplicationStarted is raised before the Run method of the application is invoked.
+	EventApplicationStarted LifecycleEventKind = "application-started"
+	// EventApplicationStopped is raised after the Run method of the application has returned; the event carries the error returned by Run, if any.
+	EventApplicationStopped LifecycleEventKind = "application-stopped"
+)
+
+// LifecycleEvent describes a lifecycle event of an application.
+type LifecycleEvent struct {
+	Kind LifecycleEventKind
+	// Service is the type name of the hosted service the event refers to, if any.
+	Service string
+	Err     error
+}
+
+// LifecycleObserverFunc is a function that gets notified about the lifecycle events of an application.
+type LifecycleObserverFunc func(event LifecycleEvent)
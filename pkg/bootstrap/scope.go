@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/matzefriedrich/parsley/internal/core"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const infrastructureContextKey = core.ContextKey("__parsley-infrastructure")
+
+// Scope provides access to the registry and the resolver of a running application, and creates new service scopes; scoped services
+// resolved with the context of the same scope share their instances. Frameworks, such as HTTP muxes or job runners, can use a Scope to
+// resolve services for each request or job without global variables.
+type Scope struct {
+	ctx            context.Context
+	infrastructure infrastructure
+}
+
+// ScopeFrom returns the service scope of the given context. Returns false if the context is not derived from the context of an application run by a Host, or by RunParsleyApplication.
+func ScopeFrom(ctx context.Context) (*Scope, bool) {
+	parsley, ok := ctx.Value(infrastructureContextKey).(infrastructure)
+	if !ok {
+		return nil, false
+	}
+	return &Scope{ctx: ctx, infrastructure: parsley}, true
+}
+
+// ResolverFrom returns the resolver of the application the given context belongs to. Returns false if the context is not derived from the context of an application.
+func ResolverFrom(ctx context.Context) (types.Resolver, bool) {
+	scope, ok := ScopeFrom(ctx)
+	if !ok {
+		return nil, false
+	}
+	return scope.Resolver(), true
+}
+
+// RegistryFrom returns the service registry of the application the given context belongs to. Returns false if the context is not derived from the context of an application.
+func RegistryFrom(ctx context.Context) (types.ServiceRegistry, bool) {
+	scope, ok := ScopeFrom(ctx)
+	if !ok {
+		return nil, false
+	}
+	return scope.Registry(), true
+}
+
+// Context returns the context of the scope, which is passed to the resolver to resolve services within the scope.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Resolver returns the resolver of the application.
+func (s *Scope) Resolver() types.Resolver {
+	return s.infrastructure.resolver
+}
+
+// Registry returns the service registry of the application.
+func (s *Scope) Registry() types.ServiceRegistry {
+	return s.infrastructure.registry
+}
+
+// NewScope creates a new service scope derived from the given context, for instance, the context of an HTTP request. The new scope does not share
+// scoped service instances with the current scope, but provides access to the same application; singleton services are shared by all scopes.
+func (s *Scope) NewScope(ctx context.Context) *Scope {
+	scopedCtx := context.WithValue(resolving.NewScopedContext(ctx), infrastructureContextKey, s.infrastructure)
+	return &Scope{ctx: scopedCtx, infrastructure: s.infrastructure}
+}
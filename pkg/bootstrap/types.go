@@ -6,3 +6,12 @@ import "context"
 type Application interface {
 	Run(context.Context) error
 }
+
+// HostedService provides an abstract interface for background components, like servers or workers, whose lifecycle is managed by the host.
+// Hosted services are started before the application is run and stopped in reverse order after the application has finished.
+type HostedService interface {
+	// Start starts the service; the method must not block until the service has finished.
+	Start(ctx context.Context) error
+	// Stop stops the service; the given context is canceled if the shutdown timeout expires.
+	Stop(ctx context.Context) error
+}
@@ -0,0 +1,104 @@
+// Package cli integrates Parsley with cobra: commands are registered as services, each command execution gets its own service scope,
+// and command-line flags are bound to options services.
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/matzefriedrich/parsley/internal/core"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ErrorCannotBuildRootCommand = "cannot build root command"
+	ErrorNoCommandScope         = "the command is not executed by cli.Execute"
+)
+
+var (
+
+	// ErrCannotBuildRootCommand is returned when the commands cannot be resolved from the registry.
+	ErrCannotBuildRootCommand = errors.New(ErrorCannotBuildRootCommand)
+
+	// ErrNoCommandScope is returned when a command created by RunE is executed without the resolver provided by Execute.
+	ErrNoCommandScope = errors.New(ErrorNoCommandScope)
+)
+
+const (
+	resolverContextKey = core.ContextKey("__parsley-cli-resolver")
+	commandContextKey  = core.ContextKey("__parsley-cli-command")
+)
+
+// Command is implemented by services that contribute a command to the root command built by NewRootCommand.
+type Command interface {
+	// Command creates the cobra command, including its flags and subcommands.
+	Command() *cobra.Command
+}
+
+// CommandFunc is a function that implements Command.
+type CommandFunc func() *cobra.Command
+
+var _ Command = CommandFunc(nil)
+
+// Command calls f().
+func (f CommandFunc) Command() *cobra.Command {
+	return f()
+}
+
+// Runner is implemented by services that execute a command; they are resolved for each execution of the command by RunE.
+type Runner interface {
+	Run(ctx context.Context, args []string) error
+}
+
+// NewRootCommand adds the commands of all Command services registered with the resolver's registry to the given root command.
+func NewRootCommand(resolver types.Resolver, root *cobra.Command) (*cobra.Command, error) {
+	ctx := resolving.NewScopedContext(context.Background())
+	commands, err := resolving.ResolveRequiredServices[Command](ctx, resolver)
+	if err != nil {
+		return nil, newCliError(ErrorCannotBuildRootCommand, types.WithCause(err))
+	}
+	for _, command := range commands {
+		root.AddCommand(command.Command())
+	}
+	return root, nil
+}
+
+// Execute executes the root command with the given context; commands created by RunE resolve their runners from the given resolver.
+func Execute(ctx context.Context, resolver types.Resolver, root *cobra.Command) error {
+	return root.ExecuteContext(context.WithValue(ctx, resolverContextKey, resolver))
+}
+
+// RunE creates a cobra RunE function that creates a new service scope for each execution of the command, resolves the runner service T
+// within the scope, and runs it. Scoped services, such as options bound from the flags of the command by RegisterFlagOptions, are shared
+// within the execution and closed by resolving.CloseScope afterward.
+func RunE[T Runner]() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		resolver, ok := cmd.Context().Value(resolverContextKey).(types.Resolver)
+		if !ok {
+			return newCliError(ErrorNoCommandScope)
+		}
+		ctx := context.WithValue(resolving.NewScopedContext(cmd.Context()), commandContextKey, cmd)
+		runner, err := resolving.ResolveRequiredService[T](ctx, resolver)
+		if err != nil {
+			return errors.Join(err, resolving.CloseScope(ctx))
+		}
+		runErr := runner.Run(ctx, args)
+		return errors.Join(runErr, resolving.CloseScope(ctx))
+	}
+}
+
+// CommandFrom returns the executed command of the given context. Returns false if the context does not belong to the execution of a command created by RunE.
+func CommandFrom(ctx context.Context) (*cobra.Command, bool) {
+	cmd, ok := ctx.Value(commandContextKey).(*cobra.Command)
+	return cmd, ok
+}
+
+func newCliError(msg string, initializers ...types.ParsleyErrorFunc) error {
+	err := &types.ParsleyError{Msg: msg}
+	for _, initializer := range initializers {
+		initializer(err)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/matzefriedrich/parsley/pkg/features"
+	"github.com/matzefriedrich/parsley/pkg/types"
+	"github.com/spf13/pflag"
+)
+
+const flagTagKey = "flag"
+
+// FlagSource creates an options source that binds fields with a `flag:"name"` tag to the flag with the same name. Only flags set on the
+// command line are bound; hence, default values of flags do not override values of other sources. Values of slice flags are bound element-wise.
+func FlagSource(flags *pflag.FlagSet) features.OptionsSource {
+	return features.TagSource(flagTagKey, func(key string) (string, bool) {
+		flag := flags.Lookup(key)
+		if flag == nil || !flag.Changed {
+			return "", false
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			return strings.Join(slice.GetSlice(), ","), true
+		}
+		return flag.Value.String(), true
+	})
+}
+
+// RegisterFlagOptions registers features.Options[T] as a scoped service that is bound for each command execution: the given sources are
+// applied first, followed by the flags of the executed command, so that flags set on the command line take precedence. Runners resolved by
+// RunE can depend on Options[T] to obtain their configuration.
+func RegisterFlagOptions[T any](registry types.ServiceRegistry, sources ...features.OptionsSource) error {
+	optionsType, err := types.ServiceTypeFrom(reflect.TypeOf(new(T)).Elem())
+	if err != nil {
+		return err
+	}
+	return registry.Register(func(ctx context.Context) (features.Options[T], error) {
+		cmd, ok := CommandFrom(ctx)
+		if !ok {
+			return nil, newCliError(ErrorNoCommandScope, types.WithCause(fmt.Errorf("cannot bind flags to %s", optionsType.Name())))
+		}
+		commandSources := append(sources[:len(sources):len(sources)], FlagSource(cmd.Flags()))
+		value, err := features.BindOptions[T](commandSources...)
+		if err != nil {
+			return nil, err
+		}
+		return features.NewOptions(value), nil
+	}, types.LifetimeScoped)
+}
@@ -0,0 +1,31 @@
+package features
+
+import (
+	"log/slog"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const (
+	// LoggerServiceKey is the attribute key of the consumer's service type name added to contextual loggers.
+	LoggerServiceKey = "service"
+	// LoggerPackageKey is the attribute key of the consumer's package path added to contextual loggers.
+	LoggerPackageKey = "package"
+)
+
+// RegisterLogger registers *slog.Logger as a service that provides each consuming service with a logger derived from the given logger and
+// tagged with the consumer's service type name and package path. Services resolved directly, instead of as a dependency, get the given logger.
+func RegisterLogger(registry types.ServiceRegistry, logger *slog.Logger) error {
+	return registration.RegisterTransient(registry, func(consumer types.ConsumerInfo) *slog.Logger {
+		return LoggerFor(logger, consumer)
+	})
+}
+
+// LoggerFor derives a logger tagged with the service type name and package path of the given consumer.
+func LoggerFor(logger *slog.Logger, consumer types.ConsumerInfo) *slog.Logger {
+	if consumer.ServiceType() == nil {
+		return logger
+	}
+	return logger.With(slog.String(LoggerServiceKey, consumer.Name()), slog.String(LoggerPackageKey, consumer.PackagePath()))
+}
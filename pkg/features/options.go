@@ -0,0 +1,215 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const (
+	ErrorCannotBindOptions       = "cannot bind options"
+	ErrorInvalidOptions          = "options validation failed"
+	ErrorOptionsTypeMustBeStruct = "options type must be a struct"
+)
+
+var (
+
+	// ErrCannotBindOptions is returned when a default value or the value of an options source cannot be bound to a field of an options type.
+	ErrCannotBindOptions = errors.New(ErrorCannotBindOptions)
+
+	// ErrInvalidOptions is returned when the validation of bound options fails.
+	ErrInvalidOptions = errors.New(ErrorInvalidOptions)
+
+	// ErrOptionsTypeMustBeStruct is returned when options are bound to a type that is not a struct.
+	ErrOptionsTypeMustBeStruct = errors.New(ErrorOptionsTypeMustBeStruct)
+)
+
+const defaultTagKey = "default"
+
+// Options provides access to a configuration value of type T. Services obtain their configuration by declaring an Options[T] parameter.
+type Options[T any] interface {
+	Value() T
+}
+
+// OptionsValidator is implemented by options types (and nested structs of options types) that validate their values after binding.
+type OptionsValidator interface {
+	Validate() error
+}
+
+type options[T any] struct {
+	value T
+}
+
+var _ Options[any] = &options[any]{}
+
+// Value returns the bound configuration value.
+func (o *options[T]) Value() T {
+	return o.value
+}
+
+// NewOptions creates an Options[T] that provides the given value.
+func NewOptions[T any](value T) Options[T] {
+	return &options[T]{value: value}
+}
+
+// RegisterOptions binds a value of the struct type T from the given sources and registers it as a singleton Options[T] service.
+// See BindOptions for the precedence of default values and sources.
+func RegisterOptions[T any](registry types.ServiceRegistry, sources ...OptionsSource) error {
+	value, err := BindOptions[T](sources...)
+	if err != nil {
+		return err
+	}
+	return registration.RegisterInstance(registry, NewOptions(value))
+}
+
+// BindOptions creates a value of the struct type T and binds its fields. Fields are initialized from `default:"..."` tags first; then each source
+// is applied in the given order, so that values of later sources take precedence over values of earlier sources, for instance, a JSON file,
+// followed by environment variables, followed by command-line flags. Finally, the value is validated by the Validate method of all nested
+// structs and of T itself that implement OptionsValidator.
+func BindOptions[T any](sources ...OptionsSource) (T, error) {
+	var value T
+	target := reflect.ValueOf(&value).Elem()
+	if target.Kind() != reflect.Struct {
+		return value, newOptionsError(ErrorOptionsTypeMustBeStruct, types.WithCause(fmt.Errorf("%s is of kind %s", target.Type(), target.Kind())))
+	}
+
+	err := bindTaggedFields(target, defaultTagKey, func(key string) (string, bool) {
+		return key, true
+	})
+	if err != nil {
+		return value, newOptionsError(ErrorCannotBindOptions, types.WithCause(err))
+	}
+
+	for _, source := range sources {
+		if err := source.Bind(&value); err != nil {
+			return value, newOptionsError(ErrorCannotBindOptions, types.WithCause(err))
+		}
+	}
+
+	if err := validateOptions(target); err != nil {
+		return value, newOptionsError(ErrorInvalidOptions, types.WithCause(err))
+	}
+
+	return value, nil
+}
+
+// bindTaggedFields sets each exported field of the given struct that has a tag with the specified key to the value returned by lookup for the tag value.
+// Nested structs without such a tag are bound recursively. Fields are left unchanged if lookup reports no value.
+func bindTaggedFields(target reflect.Value, tagKey string, lookup func(key string) (string, bool)) error {
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, tagged := field.Tag.Lookup(tagKey)
+		if !tagged {
+			if field.Type.Kind() == reflect.Struct {
+				if err := bindTaggedFields(target.Field(i), tagKey, lookup); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		text, found := lookup(key)
+		if !found {
+			continue
+		}
+		if err := setFieldValue(target.Field(i), text); err != nil {
+			return fmt.Errorf("cannot bind %q to field %s.%s: %w", text, targetType.Name(), field.Name, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setFieldValue parses the given text and assigns it to the field. Slices are bound from comma-separated values.
+func setFieldValue(field reflect.Value, text string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		items := make([]string, 0)
+		if len(strings.TrimSpace(text)) > 0 {
+			items = strings.Split(text, ",")
+		}
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setFieldValue(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// validateOptions calls the Validate method of nested structs first, and then of the given struct, if they implement OptionsValidator.
+// Embedded structs are not visited separately since their Validate method is promoted to the enclosing struct.
+func validateOptions(target reflect.Value) error {
+	validationErrors := make([]error, 0)
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if field.IsExported() && !field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := validateOptions(target.Field(i)); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+		}
+	}
+	if validator, ok := target.Addr().Interface().(OptionsValidator); ok {
+		if err := validator.Validate(); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func newOptionsError(msg string, initializers ...types.ParsleyErrorFunc) error {
+	err := &types.ParsleyError{Msg: msg}
+	for _, initializer := range initializers {
+		initializer(err)
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+package features
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/matzefriedrich/parsley/pkg/registration"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+// DefaultOptionsPollInterval defines how often an OptionsMonitor checks its sources for changes, unless configured otherwise.
+const DefaultOptionsPollInterval = 5 * time.Second
+
+// OptionsMonitor provides access to a configuration value of type T that is re-bound and re-validated when its sources change, so that
+// long-living services can reconfigure themselves without a restart. The monitor polls its sources while it is started; it implements
+// the bootstrap.HostedService interface, so that applications can have the host start and stop it by using bootstrap.RegisterHostedService.
+type OptionsMonitor[T any] interface {
+	// Current returns the most recently bound valid value.
+	Current() T
+	// OnChange registers a callback that is invoked with the new value after the options have been reloaded. The returned function removes the callback.
+	OnChange(callback func(value T)) func()
+	// Start starts polling the sources for changes.
+	Start(ctx context.Context) error
+	// Stop stops polling the sources; it waits for a reload in progress to complete, or until the given context is done.
+	Stop(ctx context.Context) error
+}
+
+// OptionsMonitorConfig holds the configuration of an OptionsMonitor.
+type OptionsMonitorConfig struct {
+	// PollInterval defines how often the sources are checked for changes; DefaultOptionsPollInterval is used if not set.
+	PollInterval time.Duration
+	// ReloadFailed is invoked if changed options cannot be bound or are invalid; the monitor keeps the previous value in this case.
+	ReloadFailed func(err error)
+}
+
+type optionsChangeCallback[T any] struct {
+	id       int
+	callback func(value T)
+}
+
+type optionsMonitor[T any] struct {
+	sources   []OptionsSource
+	config    OptionsMonitorConfig
+	m         sync.RWMutex
+	current   T
+	callbacks []optionsChangeCallback[T]
+	nextID    int
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+var _ OptionsMonitor[any] = &optionsMonitor[any]{}
+var _ Options[any] = &optionsMonitor[any]{}
+
+// NewOptionsMonitor binds a value of the struct type T from the given sources, like BindOptions does, and creates an OptionsMonitor that
+// re-binds the value from all sources if any source implementing ReloadableOptionsSource reports a change.
+func NewOptionsMonitor[T any](config OptionsMonitorConfig, sources ...OptionsSource) (OptionsMonitor[T], error) {
+	value, err := BindOptions[T](sources...)
+	if err != nil {
+		return nil, err
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultOptionsPollInterval
+	}
+	return &optionsMonitor[T]{
+		sources:   sources,
+		config:    config,
+		current:   value,
+		callbacks: make([]optionsChangeCallback[T], 0),
+	}, nil
+}
+
+// RegisterOptionsMonitor creates an OptionsMonitor for the struct type T and registers it as a singleton OptionsMonitor[T] service.
+// The monitor is also registered as Options[T] service, whose Value method returns the current value. The monitor must be started to pick up
+// changes; for instance, register it with bootstrap.RegisterHostedService[features.OptionsMonitor[T]] to have the host start and stop it.
+func RegisterOptionsMonitor[T any](registry types.ServiceRegistry, config OptionsMonitorConfig, sources ...OptionsSource) error {
+	monitor, err := NewOptionsMonitor[T](config, sources...)
+	if err != nil {
+		return err
+	}
+	err = registration.RegisterInstance(registry, monitor)
+	if err != nil {
+		return err
+	}
+	return registration.RegisterInstance(registry, monitor.(Options[T]))
+}
+
+// Current returns the most recently bound valid value.
+func (o *optionsMonitor[T]) Current() T {
+	o.m.RLock()
+	defer o.m.RUnlock()
+	return o.current
+}
+
+// Value returns the current value; it makes the monitor usable as Options[T].
+func (o *optionsMonitor[T]) Value() T {
+	return o.Current()
+}
+
+// OnChange registers a callback that is invoked with the new value after the options have been reloaded.
+func (o *optionsMonitor[T]) OnChange(callback func(value T)) func() {
+	o.m.Lock()
+	defer o.m.Unlock()
+	id := o.nextID
+	o.nextID++
+	o.callbacks = append(o.callbacks, optionsChangeCallback[T]{id: id, callback: callback})
+	return func() {
+		o.m.Lock()
+		defer o.m.Unlock()
+		for i, c := range o.callbacks {
+			if c.id == id {
+				o.callbacks = append(o.callbacks[:i:i], o.callbacks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Start starts polling the sources in the configured interval; calling Start on a started monitor has no effect.
+func (o *optionsMonitor[T]) Start(_ context.Context) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.cancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.done = make(chan struct{})
+	go o.poll(ctx, o.done)
+	return nil
+}
+
+// Stop stops polling the sources.
+func (o *optionsMonitor[T]) Stop(ctx context.Context) error {
+	o.m.Lock()
+	cancel, done := o.cancel, o.done
+	o.cancel, o.done = nil, nil
+	o.m.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (o *optionsMonitor[T]) poll(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(o.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if o.changed() {
+			o.reload()
+		}
+	}
+}
+
+// changed checks whether any reloadable source reports a change.
+func (o *optionsMonitor[T]) changed() bool {
+	for _, source := range o.sources {
+		if reloadable, ok := source.(ReloadableOptionsSource); ok && reloadable.Changed() {
+			return true
+		}
+	}
+	return false
+}
+
+// reload re-binds the options from all sources and notifies the registered callbacks if the new value is valid.
+func (o *optionsMonitor[T]) reload() {
+	value, err := BindOptions[T](o.sources...)
+	if err != nil {
+		if o.config.ReloadFailed != nil {
+			o.config.ReloadFailed(err)
+		}
+		return
+	}
+
+	o.m.Lock()
+	o.current = value
+	callbacks := make([]optionsChangeCallback[T], len(o.callbacks))
+	copy(callbacks, o.callbacks)
+	o.m.Unlock()
+
+	for _, c := range callbacks {
+		c.callback(value)
+	}
+}
@@ -0,0 +1,167 @@
+package features
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	environmentTagKey = "env"
+	flagTagKey        = "flag"
+)
+
+// OptionsSource binds configuration values to the fields of an options struct. The target passed to Bind is a pointer to the struct;
+// a source only sets the fields it has values for, so that values of sources applied earlier are kept.
+type OptionsSource interface {
+	Bind(target any) error
+}
+
+// OptionsSourceFunc is a function that implements OptionsSource.
+type OptionsSourceFunc func(target any) error
+
+var _ OptionsSource = OptionsSourceFunc(nil)
+
+// Bind calls f(target).
+func (f OptionsSourceFunc) Bind(target any) error {
+	return f(target)
+}
+
+// OptionsLookupFunc returns the value for the given key and whether the value exists.
+type OptionsLookupFunc func(key string) (string, bool)
+
+// TagSource creates an OptionsSource that binds each field with a tag of the specified key to the value returned by lookup for the tag value.
+// It is the building block of the environment and flag sources and can be used to bind values from other key-value stores.
+func TagSource(tagKey string, lookup OptionsLookupFunc) OptionsSource {
+	return OptionsSourceFunc(func(target any) error {
+		value := reflect.ValueOf(target)
+		if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("target must be a pointer to a struct, got %T", target)
+		}
+		return bindTaggedFields(value.Elem(), tagKey, lookup)
+	})
+}
+
+// EnvironmentSource creates an OptionsSource that binds fields with an `env:"NAME"` tag to the environment variable prefix+NAME.
+func EnvironmentSource(prefix string) OptionsSource {
+	return TagSource(environmentTagKey, func(key string) (string, bool) {
+		return os.LookupEnv(prefix + key)
+	})
+}
+
+// FlagSetSource creates an OptionsSource that binds fields with a `flag:"name"` tag to the flag with the same name.
+// Only flags set on the command line are bound; hence, default values of flags do not override values of other sources.
+// The flag set must be parsed before the options are bound.
+func FlagSetSource(flags *flag.FlagSet) OptionsSource {
+	return TagSource(flagTagKey, func(key string) (string, bool) {
+		found := false
+		flags.Visit(func(f *flag.Flag) {
+			found = found || f.Name == key
+		})
+		if !found {
+			return "", false
+		}
+		return flags.Lookup(key).Value.String(), true
+	})
+}
+
+// ReloadableOptionsSource is an OptionsSource that detects whether its values changed since it was last bound; an OptionsMonitor re-binds
+// its options if any of its reloadable sources reports a change.
+type ReloadableOptionsSource interface {
+	OptionsSource
+	// Changed reports whether the values of the source changed since the source was last bound.
+	Changed() bool
+}
+
+type jsonFileSource struct {
+	path    string
+	m       sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+var _ ReloadableOptionsSource = &jsonFileSource{}
+
+// JSONFileSource creates a ReloadableOptionsSource that decodes the JSON file at the given path into the options struct, using the `json` tags of the struct.
+// Fields missing in the file keep their values. The source reports a change if the modification time or the size of the file differs from the time the file was last bound.
+func JSONFileSource(path string) ReloadableOptionsSource {
+	return &jsonFileSource{path: path}
+}
+
+// Bind decodes the file into the given options struct.
+func (s *jsonFileSource) Bind(target any) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	info, err := os.Stat(s.path)
+	if err != nil {
+		// Report a change once the file exists again
+		s.modTime, s.size = time.Time{}, 0
+		return err
+	}
+	s.modTime, s.size = info.ModTime(), info.Size()
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	return JSONSource(data).Bind(target)
+}
+
+// Changed reports whether the file was modified, created, or removed since it was last bound.
+func (s *jsonFileSource) Changed() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return !s.modTime.IsZero()
+	}
+	return !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+}
+
+// InMemorySource is a ReloadableOptionsSource that decodes a JSON document held in memory. Replacing the document with Update
+// triggers a reload of monitored options, for instance, to test how services react to configuration changes.
+type InMemorySource struct {
+	m       sync.Mutex
+	data    []byte
+	changed bool
+}
+
+var _ ReloadableOptionsSource = &InMemorySource{}
+
+// NewInMemorySource creates an InMemorySource holding the given JSON document.
+func NewInMemorySource(data []byte) *InMemorySource {
+	return &InMemorySource{data: data}
+}
+
+// Update replaces the JSON document of the source.
+func (s *InMemorySource) Update(data []byte) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.data = data
+	s.changed = true
+}
+
+// Bind decodes the current JSON document into the given options struct.
+func (s *InMemorySource) Bind(target any) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.changed = false
+	return JSONSource(s.data).Bind(target)
+}
+
+// Changed reports whether the document was updated since it was last bound.
+func (s *InMemorySource) Changed() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.changed
+}
+
+// JSONSource creates an OptionsSource that decodes the given JSON document into the options struct.
+func JSONSource(data []byte) OptionsSource {
+	return OptionsSourceFunc(func(target any) error {
+		return json.Unmarshal(data, target)
+	})
+}
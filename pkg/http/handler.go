@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+)
+
+// Handler creates an http.Handler that resolves the handler service T from the scope of each request and delegates the request to it.
+// Depending on the lifetime scope of its registration, the handler can depend on scoped services, for instance, a unit of work of the request.
+// The request must be passed through the RequestScope middleware.
+func Handler[T http.Handler]() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := r.Context().Value(requestScopeContextKey).(*requestScope)
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		handler, err := resolving.ResolveRequiredService[T](r.Context(), scope.resolver)
+		if err != nil {
+			scope.options.ResolveFailed(w, r, err)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Route associates a request pattern of an http.ServeMux with a handler.
+type Route struct {
+	Pattern string
+	Handler http.Handler
+}
+
+// MapRoute creates a Route for the given pattern, for instance, "GET /orders/{id}", whose requests are served by the handler service T resolved per request.
+func MapRoute[T http.Handler](pattern string) Route {
+	return Route{Pattern: pattern, Handler: Handler[T]()}
+}
+
+// RegisterRoutes registers the handlers of the given routes with the mux.
+func RegisterRoutes(mux *http.ServeMux, routes ...Route) {
+	for _, route := range routes {
+		mux.Handle(route.Pattern, route.Handler)
+	}
+}
@@ -0,0 +1,67 @@
+// Package http integrates Parsley with net/http: a middleware creates a service scope for each request, and handlers are resolved from
+// the scope of the request they serve.
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/matzefriedrich/parsley/internal/core"
+	"github.com/matzefriedrich/parsley/pkg/resolving"
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+const requestScopeContextKey = core.ContextKey("__parsley-http-request-scope")
+
+// Middleware wraps an http.Handler.
+type Middleware func(next http.Handler) http.Handler
+
+// RequestScopeOptions holds the options of the RequestScope middleware.
+type RequestScopeOptions struct {
+	// ResolveFailed writes the response if a handler cannot be resolved; by default, the status 500 Internal Server Error is written.
+	ResolveFailed func(w http.ResponseWriter, r *http.Request, err error)
+	// CloseFailed is invoked with the errors returned by scoped services that fail to close after the request has been served.
+	CloseFailed func(r *http.Request, err error)
+}
+
+// RequestScopeOptionsFunc configures RequestScopeOptions.
+type RequestScopeOptionsFunc func(options *RequestScopeOptions)
+
+type requestScope struct {
+	resolver types.Resolver
+	options  RequestScopeOptions
+}
+
+// RequestScope creates a middleware that creates a service scope for each request. Scoped services resolved with the request context share
+// their instances during the request and are closed by resolving.CloseScope after the request has been served.
+func RequestScope(resolver types.Resolver, config ...RequestScopeOptionsFunc) Middleware {
+	options := RequestScopeOptions{
+		ResolveFailed: func(w http.ResponseWriter, _ *http.Request, _ error) {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		},
+	}
+	for _, f := range config {
+		f(&options)
+	}
+	scope := &requestScope{resolver: resolver, options: options}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(resolving.NewScopedContext(r.Context()), requestScopeContextKey, scope)
+			defer func() {
+				if err := resolving.CloseScope(ctx); err != nil && options.CloseFailed != nil {
+					options.CloseFailed(r, err)
+				}
+			}()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ResolverFrom returns the resolver of the request scope of the given context. Returns false if the context does not belong to a request passed through the RequestScope middleware.
+func ResolverFrom(ctx context.Context) (types.Resolver, bool) {
+	scope, ok := ctx.Value(requestScopeContextKey).(*requestScope)
+	if !ok {
+		return nil, false
+	}
+	return scope.resolver, true
+}
@@ -0,0 +1,61 @@
+package registration
+
+import (
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+type conditionalActivator struct {
+	activatorFunc any
+	condition     types.InjectionCondition
+}
+
+// Conditional wraps the given activator function, so that the service registration created from it applies only to consumers that meet the
+// given condition; for instance, RegisterSingleton(registry, Conditional(newReadReplica, WhenInjectedInto[*reportService]())). If the condition
+// of a registration is met, it takes precedence over the unconditional registrations of the same service type.
+func Conditional(activatorFunc any, condition types.InjectionCondition) any {
+	return conditionalActivator{activatorFunc: activatorFunc, condition: condition}
+}
+
+// WhenInjectedInto creates an InjectionCondition that is met if the service type C is the direct consumer of the dependency.
+func WhenInjectedInto[C any]() types.InjectionCondition {
+	consumerType := types.MakeServiceType[C]()
+	return func(consumers []types.ServiceType) bool {
+		return len(consumers) > 0 && consumers[0].LookupKey() == consumerType.LookupKey()
+	}
+}
+
+// SelectRegistration selects the registration that provides a dependency to the given chain of consumers: if the condition of exactly one
+// conditional registration is met, it is selected; otherwise, the only unconditional registration is selected. Returns an ErrAmbiguousConditionalRegistrations
+// error if the conditions of multiple registrations are met, and an ErrServiceTypeNotRegistered error if no registration applies.
+func SelectRegistration(list types.ServiceRegistrationList, consumers []types.ServiceType) (types.ServiceRegistration, error) {
+	matches := make([]types.ServiceRegistration, 0)
+	unconditional := make([]types.ServiceRegistration, 0)
+	serviceTypeName := ""
+	for _, registration := range list.Registrations() {
+		serviceTypeName = registration.ServiceType().Name()
+		condition := InjectionConditionOf(registration)
+		switch {
+		case condition == nil:
+			unconditional = append(unconditional, registration)
+		case condition(consumers):
+			matches = append(matches, registration)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		return nil, types.NewResolverError(types.ErrorAmbiguousConditionalRegistrations, types.ForServiceTypeByName(serviceTypeName))
+	case len(unconditional) == 1:
+		return unconditional[0], nil
+	}
+	return nil, types.NewResolverError(types.ErrorServiceTypeNotRegistered, types.ForServiceTypeByName(serviceTypeName))
+}
+
+// InjectionConditionOf returns the injection condition of the given service registration; nil if the registration applies to all consumers.
+func InjectionConditionOf(registration types.ServiceRegistration) types.InjectionCondition {
+	if conditional, ok := registration.(types.ConditionalServiceRegistration); ok {
+		return conditional.InjectionCondition()
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 	"strings"
 
 	"github.com/matzefriedrich/parsley/internal/core"
@@ -19,6 +20,7 @@ type serviceRegistration struct {
 	lifetimeScope       types.LifetimeScope
 	hasErrorReturn      bool
 	hasContextParameter bool
+	condition           types.InjectionCondition
 }
 
 type typeInfo struct {
@@ -90,11 +92,44 @@ func (s *serviceRegistration) IsSame(other types.ServiceRegistration) bool {
 		case reflect.Func:
 			return false
 		}
-		return s.activatorFunc.Pointer() == sr.activatorFunc.Pointer()
+		// Instances of a generic activator function share their code pointer, but differ in their function type
+		return s.activatorFunc.Pointer() == sr.activatorFunc.Pointer() && s.activatorFunc.Type() == sr.activatorFunc.Type()
 	}
 	return false
 }
 
+// ScopedInstanceKey returns the key under which scoped instances of the registration are stored within a scope. Registrations of top-level activator
+// functions are keyed by service type and activator function, which matches resolving.ScopedServiceKey; all other registrations are keyed by their id.
+func (s *serviceRegistration) ScopedInstanceKey() any {
+	if s.serviceType.t.ReflectedType().Kind() == reflect.Func || !isTopLevelFunction(s.activatorFunc) {
+		return s.id
+	}
+	return core.ScopedServiceKey{
+		ServiceType: s.serviceType.t.LookupKey(),
+		Activator:   s.activatorFunc.Pointer(),
+	}
+}
+
+// isTopLevelFunction reports whether the given function value refers to a declared, non-generic function rather than a closure, method value,
+// instance of a generic function, or function created by reflect.MakeFunc.
+func isTopLevelFunction(value reflect.Value) bool {
+	f := runtime.FuncForPC(value.Pointer())
+	if f == nil {
+		return false
+	}
+	name := f.Name()
+	if strings.HasPrefix(name, "reflect.") || strings.HasSuffix(name, "-fm") || strings.Contains(name, "[") {
+		return false
+	}
+	lastSegment := name[strings.LastIndex(name, "/")+1:]
+	return !strings.Contains(lastSegment, ".func")
+}
+
+// InjectionCondition returns the condition of the service registration; nil if the registration applies to all consumers.
+func (s *serviceRegistration) InjectionCondition() types.InjectionCondition {
+	return s.condition
+}
+
 // LifetimeScope returns the lifetime scope of the service registration.
 func (s *serviceRegistration) LifetimeScope() types.LifetimeScope {
 	return s.lifetimeScope
@@ -131,8 +166,12 @@ func (s *serviceRegistration) String() string {
 	return buffer.String()
 }
 
-// CreateServiceRegistration creates a service registration instance from the given activator function and lifetime scope.
+// CreateServiceRegistration creates a service registration instance from the given activator function and lifetime scope. The activator function can be wrapped by Conditional.
 func CreateServiceRegistration(activatorFunc any, lifetimeScope types.LifetimeScope) (types.ServiceRegistrationSetup, error) {
+	var condition types.InjectionCondition
+	if conditional, ok := activatorFunc.(conditionalActivator); ok {
+		activatorFunc, condition = conditional.activatorFunc, conditional.condition
+	}
 	value := reflect.ValueOf(activatorFunc)
 
 	info, err := core.ReflectFunctionInfoFrom(value)
@@ -153,6 +192,7 @@ func CreateServiceRegistration(activatorFunc any, lifetimeScope types.LifetimeSc
 		reg := newServiceRegistration(serviceType, lifetimeScope, value, requiredTypes...)
 		reg.hasErrorReturn = info.HasErrorReturn()
 		reg.hasContextParameter = info.ExpectsContextParameter()
+		reg.condition = condition
 		return reg, nil
 	default:
 		return nil, types.NewRegistryError(types.ErrorActivatorFunctionInvalidReturnType)
@@ -173,4 +213,5 @@ func newServiceRegistration(serviceType types.ServiceType, scope types.LifetimeS
 }
 
 var _ types.ServiceRegistration = &serviceRegistration{}
+var _ types.ConditionalServiceRegistration = &serviceRegistration{}
 var _ types.ServiceRegistrationSetup = &serviceRegistration{}
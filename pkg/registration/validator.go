@@ -1,7 +1,10 @@
 package registration
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/matzefriedrich/parsley/internal"
 	"github.com/matzefriedrich/parsley/internal/utils"
@@ -12,6 +15,7 @@ const (
 	ErrorFailedToRetrieveServiceRegistrations       = "failed to retrieve service registrations"
 	ErrorRegistryMissesRequiredServiceRegistrations = "the registry misses required service registrations"
 	ErrorCircularServiceRegistrationDetected        = "circular service registration detected"
+	ErrorAmbiguousConditionalRegistrationsDetected  = "conditional service registrations do not resolve to exactly one registration for each consumer"
 )
 
 var (
@@ -23,6 +27,9 @@ var (
 
 	// ErrCircularServiceRegistrationDetected signifies that a circular service registration was encountered.
 	ErrCircularServiceRegistrationDetected = types.NewResolverError(ErrorCircularServiceRegistrationDetected)
+
+	// ErrAmbiguousConditionalRegistrationsDetected indicates that a consumer matches none or multiple conditional service registrations.
+	ErrAmbiguousConditionalRegistrationsDetected = types.NewRegistryError(ErrorAmbiguousConditionalRegistrationsDetected)
 )
 
 // Validator defines an interface to validate service registries..
@@ -91,9 +98,70 @@ func (s *serviceRegistrationsValidator) Validate(registry types.ServiceRegistry)
 		return types.NewRegistryError(ErrorCircularServiceRegistrationDetected, types.WithAggregatedCause(circularDependencyErrors...))
 	}
 
+	conditionalRegistrationErrors := make([]error, 0)
+	for _, registration := range registrations {
+		conditionalRegistrationErrors = append(conditionalRegistrationErrors, detectAmbiguousConditionalRegistrations(registration, registry)...)
+	}
+
+	if len(conditionalRegistrationErrors) > 0 {
+		return types.NewRegistryError(ErrorAmbiguousConditionalRegistrationsDetected, types.WithCause(errors.Join(conditionalRegistrationErrors...)))
+	}
+
 	return nil
 }
 
+// detectAmbiguousConditionalRegistrations checks that each dependency in the dependency graph of the given registration that has conditional registrations
+// resolves to exactly one registration, evaluating the conditions with the same consumer chains the resolver builds if the registration is resolved.
+// Dependencies without conditional registrations are not checked, since they can be resolved as lists.
+func detectAmbiguousConditionalRegistrations(root types.ServiceRegistration, registry types.ServiceRegistry) []error {
+	errs := make([]error, 0)
+	if condition := InjectionConditionOf(root); condition != nil && !condition(nil) {
+		return errs // the registration applies to consumers only, whose dependency graphs are validated with their consumer chains
+	}
+
+	// Like the resolver, select the registration of each dependency with the chain of its consumers, ordered from the direct consumer to the root
+	var visit func(consumers []types.ServiceType, path []uint64, current types.ServiceRegistration)
+	visit = func(consumers []types.ServiceType, path []uint64, current types.ServiceRegistration) {
+		for _, serviceType := range current.RequiredServiceTypes() {
+			list, found := registry.TryGetServiceRegistrations(serviceType)
+			if !found {
+				continue
+			}
+			selected, err := SelectRegistration(list, consumers)
+			if err != nil {
+				if hasConditionalRegistrations(list) {
+					errs = append(errs, fmt.Errorf("cannot select service registration for service type %s injected into %s: %w", serviceType, formatConsumerChain(consumers), err))
+				}
+				continue
+			}
+			if slices.Contains(path, selected.Id()) {
+				continue // circular dependencies are reported by detectCircularDependency
+			}
+			visit(append([]types.ServiceType{selected.ServiceType()}, consumers...), append(path[:len(path):len(path)], selected.Id()), selected)
+		}
+	}
+
+	visit([]types.ServiceType{root.ServiceType()}, []uint64{root.Id()}, root)
+	return errs
+}
+
+func formatConsumerChain(consumers []types.ServiceType) string {
+	names := make([]string, 0, len(consumers))
+	for _, consumer := range consumers {
+		names = append(names, consumer.Name())
+	}
+	return strings.Join(names, " required by ")
+}
+
+func hasConditionalRegistrations(list types.ServiceRegistrationList) bool {
+	for _, registration := range list.Registrations() {
+		if InjectionConditionOf(registration) != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func detectCircularDependency(sr types.ServiceRegistration, registry types.ServiceRegistry) error {
 
 	stack := internal.MakeStack[types.ServiceRegistration]()
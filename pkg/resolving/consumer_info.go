@@ -0,0 +1,61 @@
+package resolving
+
+import (
+	"reflect"
+
+	"github.com/matzefriedrich/parsley/pkg/types"
+)
+
+type consumerInfo struct {
+	serviceType types.ServiceType
+}
+
+var _ types.ConsumerInfo = &consumerInfo{}
+
+var consumerInfoServiceType = types.MakeServiceType[types.ConsumerInfo]()
+
+func newConsumerInfo(consumer types.DependencyInfo) types.ConsumerInfo {
+	if consumer == nil {
+		return &consumerInfo{}
+	}
+	return &consumerInfo{serviceType: consumer.Registration().ServiceType()}
+}
+
+// ServiceType returns the service type of the consumer.
+func (c *consumerInfo) ServiceType() types.ServiceType {
+	return c.serviceType
+}
+
+// Name returns the name of the consumer's service type.
+func (c *consumerInfo) Name() string {
+	if c.serviceType == nil {
+		return ""
+	}
+	return c.serviceType.Name()
+}
+
+// PackagePath returns the import path of the package that declares the consumer's service type; pointer types are dereferenced.
+func (c *consumerInfo) PackagePath() string {
+	if c.serviceType == nil {
+		return ""
+	}
+	t := c.serviceType.ReflectedType()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath()
+}
+
+// requiresConsumerInfo checks whether the activator function of the given registration accepts a types.ConsumerInfo parameter.
+func requiresConsumerInfo(registration types.ServiceRegistration) bool {
+	for _, requiredType := range registration.RequiredServiceTypes() {
+		if isConsumerInfo(requiredType) {
+			return true
+		}
+	}
+	return false
+}
+
+func isConsumerInfo(serviceType types.ServiceType) bool {
+	return serviceType.LookupKey() == consumerInfoServiceType.LookupKey()
+}
@@ -62,6 +62,9 @@ func NewResolver(registry types.ServiceRegistry) types.Resolver {
 		globalInstances: core.NewGlobalInstanceBag(),
 	}
 	_ = registration.RegisterInstance[types.Resolver](registry, r)
+	_ = registration.RegisterTransient(registry, func() types.ConsumerInfo {
+		return newConsumerInfo(nil)
+	})
 	return r
 }
 
@@ -83,6 +86,15 @@ func detectCircularDependency(sr types.ServiceRegistration, consumer types.Depen
 	return nil
 }
 
+// consumerChainOf returns the service types of the given dependency and its consumers, ordered from the given dependency to the service being resolved.
+func consumerChainOf(consumer types.DependencyInfo) []types.ServiceType {
+	chain := make([]types.ServiceType, 0)
+	for next := consumer; next != nil; next = next.Consumer() {
+		chain = append(chain, next.Registration().ServiceType())
+	}
+	return chain
+}
+
 func (r *resolver) createResolverRegistryAccessor(resolverOptions ...types.ResolverOptionsFunc) (types.ServiceRegistryAccessor, error) {
 	if len(resolverOptions) > 0 {
 		transientRegistry := r.registry.CreateLinkedRegistry()
@@ -117,6 +129,11 @@ func (r *resolver) ResolveWithOptions(ctx context.Context, serviceType types.Ser
 
 	for _, serviceRegistration := range serviceRegistrationList.Registrations() {
 
+		// Conditional registrations apply to services resolved directly only if their condition is met without consumers
+		if condition := registration.InjectionConditionOf(serviceRegistration); condition != nil && !condition(nil) {
+			continue
+		}
+
 		makeDependencyInfo := func(sr types.ServiceRegistration, consumer types.DependencyInfo) (types.DependencyInfo, error) {
 			instance, _ := r.globalInstances.TryResolveInstance(ctx, serviceRegistration)
 			err := detectCircularDependency(sr, consumer)
@@ -135,10 +152,19 @@ func (r *resolver) ResolveWithOptions(ctx context.Context, serviceType types.Ser
 			resolverStack.Push(next)
 			requiredServices := next.RequiredServiceTypes()
 			for _, requiredService := range requiredServices {
-				requiredServiceRegistration, isRegistered := registry.TryGetSingleServiceRegistration(requiredService)
+				requiredServiceRegistrations, isRegistered := registry.TryGetServiceRegistrations(requiredService)
 				if !isRegistered {
 					return nil, types.NewResolverError(types.ErrorServiceTypeNotRegistered, types.ForServiceTypeByName(requiredService.Name()))
 				}
+				requiredServiceRegistration, err := registration.SelectRegistration(requiredServiceRegistrations, consumerChainOf(next))
+				if err != nil {
+					return nil, err
+				}
+				if isConsumerInfo(requiredService) {
+					// The consumer info describes the service that requires the dependency, which is unique for each dependency info
+					next.AddRequiredServiceInfo(registration.NewDependencyInfo(requiredServiceRegistration, newConsumerInfo(next.Consumer()), next))
+					continue
+				}
 				child, err := makeDependencyInfo(requiredServiceRegistration, next)
 				if err != nil {
 					return nil, types.NewResolverError(types.ErrorCannotBuildDependencyGraph, types.WithCause(err), types.ForServiceTypeByName(requiredService.Name()))
@@ -157,10 +183,14 @@ func (r *resolver) ResolveWithOptions(ctx context.Context, serviceType types.Ser
 
 			next := resolverStack.Pop()
 			nextRegistration := next.Registration()
-			instance, ok := instances.TryResolveInstance(ctx, nextRegistration)
-			if ok {
-				_ = next.SetInstance(instance)
-				continue
+			// Instances that depend on their consumer are neither shared nor kept
+			consumerSpecific := requiresConsumerInfo(nextRegistration)
+			if !consumerSpecific {
+				instance, ok := instances.TryResolveInstance(ctx, nextRegistration)
+				if ok {
+					_ = next.SetInstance(instance)
+					continue
+				}
 			}
 
 			instance, err := next.CreateInstance(ctx)
@@ -168,7 +198,9 @@ func (r *resolver) ResolveWithOptions(ctx context.Context, serviceType types.Ser
 				return nil, types.NewResolverError(types.ErrorCannotResolveService, types.WithCause(err), types.ForServiceTypeByName(next.ServiceTypeName()))
 			}
 
-			instances.KeepInstance(ctx, nextRegistration, instance)
+			if !consumerSpecific {
+				instances.KeepInstance(ctx, nextRegistration, instance)
+			}
 		}
 
 		resolvedInstances = append(resolvedInstances, root.Instance())
@@ -2,11 +2,65 @@ package resolving
 
 import (
 	"context"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+
 	"github.com/matzefriedrich/parsley/internal/core"
+	"github.com/matzefriedrich/parsley/pkg/types"
 )
 
 // NewScopedContext creates a new context with an associated service instance map, useful for managing service lifetimes within scope.
 func NewScopedContext(ctx context.Context) context.Context {
-	instances := make(map[uint64]interface{})
-	return context.WithValue(ctx, core.ParsleyContext, instances)
+	return context.WithValue(ctx, core.ParsleyContext, core.NewScopedInstances())
+}
+
+// CloseScope ends the service scope of the given context, which must be created by NewScopedContext. Scoped service instances that implement
+// io.Closer are closed in the reverse order of their creation, so that services are closed before their dependencies; errors are aggregated.
+// Scoped services resolved with the context afterward are created anew.
+func CloseScope(ctx context.Context) error {
+	scopedInstances, ok := ctx.Value(core.ParsleyContext).(*core.ScopedInstances)
+	if !ok {
+		return nil
+	}
+	instances := scopedInstances.Release()
+	slices.Reverse(instances)
+	closeErrors := make([]error, 0)
+	for _, instance := range instances {
+		if closer, ok := instance.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				closeErrors = append(closeErrors, err)
+			}
+		}
+	}
+	return errors.Join(closeErrors...)
+}
+
+// ScopedServiceKey returns the key under which the scoped instance of service type T, created by the given activator function, is stored within a scope.
+// The resolver stores scoped instances of services registered with a top-level activator function under the same key, so that ResolveScoped and the resolver share instances.
+func ScopedServiceKey[T any](activatorFunc any) any {
+	return core.ScopedServiceKey{
+		ServiceType: types.MakeServiceType[T]().LookupKey(),
+		Activator:   reflect.ValueOf(activatorFunc).Pointer(),
+	}
+}
+
+// ResolveScoped returns the instance stored under the given key within the scope of the given context, or creates and stores it using the activator function.
+// The key must be comparable; use ScopedServiceKey or an unexported key type to avoid collisions. If the context was not created by NewScopedContext, the activator function gets called for each request.
+// This function supports generated code, such as containers created by the parsley-cli generate wiring command.
+func ResolveScoped[T any](ctx context.Context, key any, activatorFunc func() (T, error)) (T, error) {
+	scopedInstances, hasScope := ctx.Value(core.ParsleyContext).(*core.ScopedInstances)
+	if !hasScope {
+		return activatorFunc()
+	}
+	instance, err := scopedInstances.GetOrCreate(key, func() (any, error) {
+		return activatorFunc()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	t, _ := instance.(T)
+	return t, nil
 }
@@ -17,6 +17,8 @@ const (
 	ErrorServiceTypeMustBeInterface             = "service type must be an interface"
 	ErrorCannotRegisterTypeWithResolverOptions  = "cannot register type with resolver options"
 	ErrorCannotCreateInstanceOfUnregisteredType = "failed to create instance of unregistered type"
+	ErrorAmbiguousConditionalRegistrations      = "multiple conditional service registrations apply to the consumer"
+	ErrorResolverOptionsNotSupported            = "the resolver does not support resolver options"
 )
 
 var (
@@ -47,6 +49,12 @@ var (
 
 	// ErrCannotCreateInstanceOfUnregisteredType is returned when the resolver fails to instantiate a type that has not been registered.
 	ErrCannotCreateInstanceOfUnregisteredType = errors.New(ErrorCannotCreateInstanceOfUnregisteredType)
+
+	// ErrAmbiguousConditionalRegistrations is returned when the conditions of more than one registration of a service type are met for a consumer.
+	ErrAmbiguousConditionalRegistrations = errors.New(ErrorAmbiguousConditionalRegistrations)
+
+	// ErrResolverOptionsNotSupported is returned by resolvers that cannot apply resolver options, such as generated containers.
+	ErrResolverOptionsNotSupported = errors.New(ErrorResolverOptionsNotSupported)
 )
 
 // ResolverError represents an error that gets returned for failing service resolver operations.
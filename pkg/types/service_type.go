@@ -1,10 +1,21 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
 
+const (
+	ErrorUnsupportedServiceType = "unsupported service type"
+)
+
+var (
+
+	// ErrUnsupportedServiceType is returned when a service type is derived from a type of an unsupported kind.
+	ErrUnsupportedServiceType = errors.New(ErrorUnsupportedServiceType)
+)
+
 type serviceType struct {
 	reflectedType reflect.Type
 	name          string
@@ -41,14 +52,15 @@ func (s serviceType) PackagePath() string {
 }
 
 // MakeServiceType creates a ServiceType instance for the specified generic type T.
+// The function panics if T is of an unsupported kind; use ServiceTypeFrom to handle such types gracefully.
 func MakeServiceType[T any]() ServiceType {
 	elem := reflect.TypeOf(new(T)).Elem()
-	return ServiceTypeFrom(elem)
+	return MustServiceTypeFrom(elem)
 }
 
 // ServiceTypeFrom creates a ServiceType from the given reflect.Type.
-// Supports pointer, interface, function, slice, and struct types. The function panics, if t is of an unsupported kind is given.
-func ServiceTypeFrom(t reflect.Type) ServiceType {
+// Supports pointer, interface, function, slice, and struct types. Returns an ErrUnsupportedServiceType error if t is of an unsupported kind.
+func ServiceTypeFrom(t reflect.Type) (ServiceType, error) {
 	isList := false
 	elemType := t
 	switch t.Kind() {
@@ -63,9 +75,18 @@ func ServiceTypeFrom(t reflect.Type) ServiceType {
 		isList = true
 	case reflect.Struct:
 	default:
-		panic("unsupported type: " + t.String())
+		return nil, NewReflectionError(ErrorUnsupportedServiceType, WithCause(fmt.Errorf("%s is of kind %s", t, t.Kind())))
+	}
+	return newServiceType(t, elemType, isList), nil
+}
+
+// MustServiceTypeFrom is like ServiceTypeFrom but panics if t is of an unsupported kind.
+func MustServiceTypeFrom(t reflect.Type) ServiceType {
+	serviceType, err := ServiceTypeFrom(t)
+	if err != nil {
+		panic(err)
 	}
-	return newServiceType(t, elemType, isList)
+	return serviceType
 }
 
 func newServiceType(t reflect.Type, elemType reflect.Type, isList bool) ServiceType {
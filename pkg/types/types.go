@@ -116,6 +116,18 @@ type ServiceRegistration interface {
 	ServiceType() ServiceType
 }
 
+// InjectionCondition decides whether a conditional service registration applies to a dependency. The consumers are the service types of the
+// services that require the dependency, ordered from the direct consumer to the service being resolved; the chain is empty if the service is resolved directly.
+type InjectionCondition func(consumers []ServiceType) bool
+
+// ConditionalServiceRegistration is implemented by service registrations that can be restricted to specific consumers.
+type ConditionalServiceRegistration interface {
+	ServiceRegistration
+
+	// InjectionCondition returns the condition of the service registration; nil if the registration applies to all consumers.
+	InjectionCondition() InjectionCondition
+}
+
 // ServiceRegistrationList provides functionality to manage a list of service registrations. This interface supports internal infrastructure services.
 type ServiceRegistrationList interface {
 
@@ -160,6 +172,19 @@ type Resolver interface {
 	ResolveWithOptions(ctx context.Context, serviceType ServiceType, options ...ResolverOptionsFunc) ([]interface{}, error)
 }
 
+// ConsumerInfo describes the service that requires a dependency. Activator functions with a ConsumerInfo parameter create a dedicated instance
+// for each consuming service, regardless of their lifetime scope, for instance, a logger tagged with the name of the consumer.
+type ConsumerInfo interface {
+	// ServiceType returns the service type of the consumer; nil if the service is resolved directly instead of as a dependency of another service.
+	ServiceType() ServiceType
+
+	// Name returns the name of the consumer's service type, for instance, orderService; empty if there is no consumer.
+	Name() string
+
+	// PackagePath returns the import path of the package that declares the consumer's service type; empty if there is no consumer.
+	PackagePath() string
+}
+
 // DependencyInfo provides functionality to manage dependency information.
 type DependencyInfo interface {
 	// AddRequiredServiceInfo adds a child dependency to the current dependency info.